@@ -1,6 +1,8 @@
 package excel
 
 import (
+	"strings"
+
 	"github.com/xuri/excelize/v2"
 )
 
@@ -9,20 +11,11 @@ func ColumnNumberToText(columnNumber int) (string, error) {
 	return excelize.ColumnNumberToName(columnNumber)
 }
 
-// ColumnTextToNumber 列文字转索引
+// ColumnTextToNumber 列文字转索引（不区分大小写，忽略绝对引用标记$，无效时返回0）
 func ColumnTextToNumber(columnText string) int {
-	result := 0
-	for i, char := range columnText {
-		result += (int(char - 'A' + 1)) * pow(26, len(columnText)-i-1)
-	}
-	return result
-}
-
-// pow 是一个简单的幂函数计算，用于26进制转换
-func pow(base, exponent int) int {
-	result := 1
-	for i := 0; i < exponent; i++ {
-		result *= base
+	columnNumber, err := parseColumn(strings.TrimPrefix(strings.TrimSpace(columnText), "$"))
+	if err != nil {
+		return 0
 	}
-	return result
+	return columnNumber
 }
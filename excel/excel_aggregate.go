@@ -0,0 +1,707 @@
+package excel
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type (
+	// ExcelAggregateFunc 汇总方式
+	ExcelAggregateFunc string
+
+	// ExcelAggregateMeasure 汇总列
+	ExcelAggregateMeasure struct {
+		title       string
+		fn          ExcelAggregateFunc
+		outputTitle string
+	}
+
+	// ExcelAggregate 分组汇总（按一列或多列分组，逐级输出小计行，最后输出合计行；小计、合计使用SUM等公式，便于在Excel中核对）
+	ExcelAggregate struct {
+		titles           []string
+		records          []*excelAggregateRecord
+		groupTitles      []string
+		measures         []*ExcelAggregateMeasure
+		titleRow         uint64
+		detail           bool
+		subtotal         bool
+		grandTotal       bool
+		subtotalLabel    string
+		grandTotalLabel  string
+		subtotalStyler   ExcelCellStyler
+		grandTotalStyler ExcelCellStyler
+		numberFormats    map[string]string
+		err              error
+	}
+
+	// excelAggregateRecord 源数据行
+	excelAggregateRecord struct {
+		rowNumber uint64
+		values    map[string]any
+	}
+
+	// excelAggregateGroup 分组（depth为0时为全部数据）
+	excelAggregateGroup struct {
+		depth    int
+		values   []any
+		records  []*excelAggregateRecord
+		children []*excelAggregateGroup
+		index    map[string]*excelAggregateGroup
+	}
+
+	// excelAggregateRange 数据行区间
+	excelAggregateRange struct {
+		start int
+		end   int
+	}
+)
+
+const (
+	ExcelAggregateSum   ExcelAggregateFunc = "sum"
+	ExcelAggregateCount ExcelAggregateFunc = "count"
+	ExcelAggregateAvg   ExcelAggregateFunc = "avg"
+	ExcelAggregateMax   ExcelAggregateFunc = "max"
+	ExcelAggregateMin   ExcelAggregateFunc = "min"
+
+	// excelFormulaMaxArgs Excel函数参数个数上限
+	excelFormulaMaxArgs = 255
+)
+
+var (
+	// excelAggregateLabels 汇总方式名称（用于默认列名）
+	excelAggregateLabels = map[ExcelAggregateFunc]string{
+		ExcelAggregateSum:   "求和",
+		ExcelAggregateCount: "计数",
+		ExcelAggregateAvg:   "平均值",
+		ExcelAggregateMax:   "最大值",
+		ExcelAggregateMin:   "最小值",
+	}
+	// excelAggregateFormulas 汇总方式对应的Excel函数（第二项为明细行为汇总结果时使用的函数）
+	excelAggregateFormulas = map[ExcelAggregateFunc][2]string{
+		ExcelAggregateSum:   {"SUM", "SUM"},
+		ExcelAggregateCount: {"COUNTA", "SUM"},
+		ExcelAggregateAvg:   {"AVERAGE", ""},
+		ExcelAggregateMax:   {"MAX", "MAX"},
+		ExcelAggregateMin:   {"MIN", "MIN"},
+	}
+)
+
+// NewExcelAggregateByReader 构造函数（使用已读取的数据，须已调用ReadTitle、Read，重复的表头依次追加序号）
+func NewExcelAggregateByReader(reader *ExcelReader) *ExcelAggregate {
+	r := newExcelAggregate()
+	r.titles, r.records, r.err = aggregateRecordsByReader(reader)
+	return r
+}
+
+// NewExcelAggregateByStructs 构造函数（使用结构体切片，列名由`excel:"标题"`标签决定，同WriteStructs）
+func NewExcelAggregateByStructs(data any) *ExcelAggregate {
+	r := newExcelAggregate()
+	r.titles, r.records, r.err = aggregateRecordsByStructs(data)
+	return r
+}
+
+// aggregateRecordsByReader 读取器中的数据转为源数据行
+func aggregateRecordsByReader(reader *ExcelReader) ([]string, []*excelAggregateRecord, error) {
+	if err := reader.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(reader.GetTitle()) == 0 {
+		return nil, nil, ErrTitleNotSet
+	}
+
+	var (
+		titles  = UniqueTitles(reader.GetTitle())
+		records []*excelAggregateRecord
+		data    = reader.ToMap("")
+	)
+	for _, rowNumber := range reader.GetRowNumbers() {
+		values := make(map[string]any, len(data[rowNumber]))
+		for title, value := range data[rowNumber] {
+			values[title] = value
+		}
+		records = append(records, &excelAggregateRecord{rowNumber: reader.GetExcelRowNumber(rowNumber), values: values})
+	}
+
+	return titles, records, nil
+}
+
+// aggregateRecordsByStructs 结构体切片转为源数据行
+func aggregateRecordsByStructs(data any) ([]string, []*excelAggregateRecord, error) {
+	sliceValue := reflect.ValueOf(data)
+	for sliceValue.Kind() == reflect.Pointer {
+		sliceValue = sliceValue.Elem()
+	}
+	if sliceValue.Kind() != reflect.Slice && sliceValue.Kind() != reflect.Array {
+		return nil, nil, errors.New("数据必须为切片")
+	}
+
+	structType := sliceValue.Type().Elem()
+	for structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, nil, errors.New("切片元素必须为结构体")
+	}
+
+	columns, err := structColumns(structType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		titles  = make([]string, len(columns))
+		records []*excelAggregateRecord
+	)
+	for idx, column := range columns {
+		titles[idx] = column.title
+	}
+	for idx := 0; idx < sliceValue.Len(); idx++ {
+		elem := sliceValue.Index(idx)
+		for elem.Kind() == reflect.Pointer && !elem.IsNil() {
+			elem = elem.Elem()
+		}
+
+		values := make(map[string]any, len(columns))
+		for _, column := range columns {
+			if elem.Kind() != reflect.Struct {
+				continue
+			}
+			if field := fieldByIndexSafe(elem, column.index); field.IsValid() {
+				values[column.title] = aggregateFieldValue(field)
+			}
+		}
+		records = append(records, &excelAggregateRecord{rowNumber: uint64(idx + 1), values: values})
+	}
+
+	return titles, records, nil
+}
+
+// newExcelAggregate 默认配置（第一行是表头，输出小计及合计）
+func newExcelAggregate() *ExcelAggregate {
+	return &ExcelAggregate{
+		titleRow:         1,
+		subtotal:         true,
+		grandTotal:       true,
+		subtotalLabel:    "小计",
+		grandTotalLabel:  "合计",
+		subtotalStyler:   func(cell *ExcelCell) *ExcelCell { return cell.SetFontBold(true, true).SetFillColor("#F2F2F2", true) },
+		grandTotalStyler: func(cell *ExcelCell) *ExcelCell { return cell.SetFontBold(true, true).SetFillColor("#D9D9D9", true) },
+		numberFormats:    make(map[string]string),
+	}
+}
+
+// Err 获取错误（构造或配置时的错误，写入时同样会返回）
+func (r *ExcelAggregate) Err() error {
+	return r.err
+}
+
+// GroupBy 设置分组列（依次为一级、二级分组，分组按首次出现的顺序排列）
+func (r *ExcelAggregate) GroupBy(titles ...string) *ExcelAggregate {
+	r.groupTitles = titles
+	return r
+}
+
+// AddMeasure 添加汇总列（outputTitle为空时，求和列沿用原列名，其他为"原列名（计数）"等）
+func (r *ExcelAggregate) AddMeasure(title string, fn ExcelAggregateFunc, outputTitle string) *ExcelAggregate {
+	if _, exist := excelAggregateLabels[fn]; !exist && r.err == nil {
+		r.err = fmt.Errorf("不支持的汇总方式：%s", fn)
+	}
+	if outputTitle == "" {
+		outputTitle = title
+		if fn != ExcelAggregateSum {
+			outputTitle = fmt.Sprintf("%s（%s）", title, excelAggregateLabels[fn])
+		}
+	}
+	r.measures = append(r.measures, &ExcelAggregateMeasure{title: title, fn: fn, outputTitle: outputTitle})
+	return r
+}
+
+// Sum 添加求和列
+func (r *ExcelAggregate) Sum(titles ...string) *ExcelAggregate {
+	return r.addMeasures(ExcelAggregateSum, titles)
+}
+
+// Count 添加计数列（统计非空值个数）
+func (r *ExcelAggregate) Count(titles ...string) *ExcelAggregate {
+	return r.addMeasures(ExcelAggregateCount, titles)
+}
+
+// Avg 添加平均值列（忽略空值）
+func (r *ExcelAggregate) Avg(titles ...string) *ExcelAggregate {
+	return r.addMeasures(ExcelAggregateAvg, titles)
+}
+
+// Max 添加最大值列
+func (r *ExcelAggregate) Max(titles ...string) *ExcelAggregate {
+	return r.addMeasures(ExcelAggregateMax, titles)
+}
+
+// Min 添加最小值列
+func (r *ExcelAggregate) Min(titles ...string) *ExcelAggregate {
+	return r.addMeasures(ExcelAggregateMin, titles)
+}
+
+// addMeasures 批量添加汇总列
+func (r *ExcelAggregate) addMeasures(fn ExcelAggregateFunc, titles []string) *ExcelAggregate {
+	for _, title := range titles {
+		r.AddMeasure(title, fn, "")
+	}
+	return r
+}
+
+// GetMeasures 获取汇总列
+func (r *ExcelAggregate) GetMeasures() []*ExcelAggregateMeasure {
+	return r.measures
+}
+
+// GetTitle 获取源数据列名
+func (r *ExcelAggregateMeasure) GetTitle() string {
+	return r.title
+}
+
+// GetFunc 获取汇总方式
+func (r *ExcelAggregateMeasure) GetFunc() ExcelAggregateFunc {
+	return r.fn
+}
+
+// GetOutputTitle 获取输出列名
+func (r *ExcelAggregateMeasure) GetOutputTitle() string {
+	return r.outputTitle
+}
+
+// GetTitleRow 获取表头行
+func (r *ExcelAggregate) GetTitleRow() uint64 {
+	return r.titleRow
+}
+
+// SetTitleRow 设置表头行
+func (r *ExcelAggregate) SetTitleRow(titleRow uint64) *ExcelAggregate {
+	r.titleRow = titleRow
+	return r
+}
+
+// GetDetail 获取是否输出明细行
+func (r *ExcelAggregate) GetDetail() bool {
+	return r.detail
+}
+
+// SetDetail 设置是否输出明细行（默认每个末级分组输出一行汇总结果；开启后逐行输出源数据，每个分组之后输出小计行）
+func (r *ExcelAggregate) SetDetail(detail bool) *ExcelAggregate {
+	r.detail = detail
+	return r
+}
+
+// SetSubtotal 设置是否输出小计行（默认输出）
+func (r *ExcelAggregate) SetSubtotal(subtotal bool) *ExcelAggregate {
+	r.subtotal = subtotal
+	return r
+}
+
+// SetGrandTotal 设置是否输出合计行（默认输出）
+func (r *ExcelAggregate) SetGrandTotal(grandTotal bool) *ExcelAggregate {
+	r.grandTotal = grandTotal
+	return r
+}
+
+// SetSubtotalLabel 设置小计行名称（默认为：小计，输出为"分组值 小计"）
+func (r *ExcelAggregate) SetSubtotalLabel(subtotalLabel string) *ExcelAggregate {
+	r.subtotalLabel = subtotalLabel
+	return r
+}
+
+// SetGrandTotalLabel 设置合计行名称（默认为：合计）
+func (r *ExcelAggregate) SetGrandTotalLabel(grandTotalLabel string) *ExcelAggregate {
+	r.grandTotalLabel = grandTotalLabel
+	return r
+}
+
+// SetSubtotalStyler 设置小计行样式（默认加粗、浅灰色填充，nil为不设置样式）
+func (r *ExcelAggregate) SetSubtotalStyler(styler ExcelCellStyler) *ExcelAggregate {
+	r.subtotalStyler = styler
+	return r
+}
+
+// SetGrandTotalStyler 设置合计行样式（默认加粗、灰色填充，nil为不设置样式）
+func (r *ExcelAggregate) SetGrandTotalStyler(styler ExcelCellStyler) *ExcelAggregate {
+	r.grandTotalStyler = styler
+	return r
+}
+
+// SetNumberFormat 设置汇总列数字格式（根据输出列名）
+func (r *ExcelAggregate) SetNumberFormat(outputTitle, numberFormat string) *ExcelAggregate {
+	r.numberFormats[outputTitle] = numberFormat
+	return r
+}
+
+// GetTitle 获取源数据列名
+func (r *ExcelAggregate) GetTitle() []string {
+	return r.titles
+}
+
+// GetOutputTitle 获取输出列名（分组列在前，汇总列在后）
+func (r *ExcelAggregate) GetOutputTitle() []string {
+	titles := append([]string{}, r.groupTitles...)
+	for _, measure := range r.measures {
+		titles = append(titles, measure.outputTitle)
+	}
+	return titles
+}
+
+// check 检查配置
+func (r *ExcelAggregate) check() error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.groupTitles) == 0 {
+		return errors.New("未设置分组列")
+	}
+	if len(r.measures) == 0 {
+		return errors.New("未设置汇总列")
+	}
+
+	var missing []string
+	for _, title := range r.groupTitles {
+		if !containsString(r.titles, title) && !containsString(missing, title) {
+			missing = append(missing, title)
+		}
+	}
+	for _, measure := range r.measures {
+		if !containsString(r.titles, measure.title) && !containsString(missing, measure.title) {
+			missing = append(missing, measure.title)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w：%s", ErrTitleRequired, strings.Join(missing, "、"))
+	}
+
+	return nil
+}
+
+// group 分组
+func (r *ExcelAggregate) group() *excelAggregateGroup {
+	root := &excelAggregateGroup{index: make(map[string]*excelAggregateGroup)}
+
+	for _, record := range r.records {
+		root.records = append(root.records, record)
+		group := root
+		for depth, title := range r.groupTitles {
+			value := record.values[title]
+			key := fmt.Sprint(value)
+			child, exist := group.index[key]
+			if !exist {
+				child = &excelAggregateGroup{depth: depth + 1, values: append(append([]any{}, group.values...), value), index: make(map[string]*excelAggregateGroup)}
+				group.index[key] = child
+				group.children = append(group.children, child)
+			}
+			child.records = append(child.records, record)
+			group = child
+		}
+	}
+
+	return root
+}
+
+// aggregateMeasureValue 计算汇总结果（没有可汇总的值时为nil，titles用于定位出错的列）
+func aggregateMeasureValue(titles []string, measure *ExcelAggregateMeasure, records []*excelAggregateRecord) (any, error) {
+	var (
+		count  int
+		result float64
+	)
+
+	for _, record := range records {
+		value := record.values[measure.title]
+		if measure.fn == ExcelAggregateCount {
+			if !isAggregateEmpty(value) {
+				count++
+			}
+			continue
+		}
+
+		number, ok, err := aggregateNumber(value)
+		if err != nil {
+			return nil, NewCellError(record.rowNumber, indexOfString(titles, measure.title)+1, measure.title, fmt.Sprint(value), err)
+		}
+		if !ok {
+			continue
+		}
+		switch {
+		case count == 0:
+			result = number
+		case measure.fn == ExcelAggregateSum || measure.fn == ExcelAggregateAvg:
+			result += number
+		case measure.fn == ExcelAggregateMax:
+			result = math.Max(result, number)
+		case measure.fn == ExcelAggregateMin:
+			result = math.Min(result, number)
+		}
+		count++
+	}
+
+	switch {
+	case measure.fn == ExcelAggregateCount:
+		return count, nil
+	case measure.fn == ExcelAggregateSum:
+		return result, nil
+	case count == 0:
+		return nil, nil
+	case measure.fn == ExcelAggregateAvg:
+		return result / float64(count), nil
+	default:
+		return result, nil
+	}
+}
+
+// WriteAggregate 写入分组汇总结果：分组列在前、汇总列在后；小计行、合计行的求和、计数、最大值、最小值使用公式引用明细行，
+// 未开启SetDetail时平均值无法由各组平均值汇总，小计行、合计行写入计算结果
+func (r *ExcelWriter) WriteAggregate(aggregate *ExcelAggregate) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if err := aggregate.check(); err != nil {
+		return r.fail(err)
+	}
+
+	r.SetTitleRow(aggregate.GetOutputTitle(), aggregate.GetTitleRow())
+
+	var (
+		rowNumber = int(aggregate.GetTitleRow()) + 1
+		groupCols = len(aggregate.groupTitles)
+	)
+
+	addRow := func(cells []*ExcelCell, styler ExcelCellStyler) {
+		for idx, cell := range cells {
+			if idx >= groupCols {
+				if numberFormat, exist := aggregate.numberFormats[aggregate.measures[idx-groupCols].outputTitle]; exist {
+					cell.SetNumberFormat(numberFormat)
+				}
+			}
+			if styler != nil {
+				cells[idx] = styler(cell)
+			}
+		}
+		r.AddRow(NewExcelRow().SetCollectErr(r.collectErr).SetRowNumber(uint64(rowNumber)).SetCells(cells))
+		rowNumber++
+	}
+
+	// totalRow 小计、合计行（label写入分组所在列，上级分组列沿用分组值）
+	totalRow := func(group *excelAggregateGroup, ranges []excelAggregateRange, label string, styler ExcelCellStyler) error {
+		cells := make([]*ExcelCell, 0, groupCols+len(aggregate.measures))
+		for idx := 0; idx < groupCols; idx++ {
+			switch {
+			case idx < group.depth-1:
+				cells = append(cells, newExcelCellByValue(group.values[idx]))
+			case idx == group.depth-1:
+				cells = append(cells, NewExcelCellAny(strings.TrimSpace(fmt.Sprintf("%v %s", group.values[idx], label))))
+			case idx == 0:
+				cells = append(cells, NewExcelCellAny(label))
+			default:
+				cells = append(cells, NewExcelCellAny(""))
+			}
+		}
+		for idx, measure := range aggregate.measures {
+			formula := aggregateFormula(measure.fn, aggregate.detail, groupCols+idx+1, ranges)
+			if formula != "" {
+				cells = append(cells, NewExcelCellFormula(formula))
+				continue
+			}
+			value, err := aggregateMeasureValue(aggregate.titles, measure, group.records)
+			if err != nil {
+				return err
+			}
+			cells = append(cells, newExcelCellByValue(value))
+		}
+		addRow(cells, styler)
+		return nil
+	}
+
+	var writeGroup func(group *excelAggregateGroup) ([]excelAggregateRange, error)
+	writeGroup = func(group *excelAggregateGroup) ([]excelAggregateRange, error) {
+		var ranges []excelAggregateRange
+
+		switch {
+		case group.depth == groupCols && !aggregate.detail:
+			cells := make([]*ExcelCell, 0, groupCols+len(aggregate.measures))
+			for _, value := range group.values {
+				cells = append(cells, newExcelCellByValue(value))
+			}
+			for _, measure := range aggregate.measures {
+				value, err := aggregateMeasureValue(aggregate.titles, measure, group.records)
+				if err != nil {
+					return nil, err
+				}
+				cells = append(cells, newExcelCellByValue(value))
+			}
+			ranges = append(ranges, excelAggregateRange{start: rowNumber, end: rowNumber})
+			addRow(cells, nil)
+			return ranges, nil
+		case group.depth == groupCols:
+			start := rowNumber
+			for _, record := range group.records {
+				cells := make([]*ExcelCell, 0, groupCols+len(aggregate.measures))
+				for _, value := range group.values {
+					cells = append(cells, newExcelCellByValue(value))
+				}
+				for _, measure := range aggregate.measures {
+					value := record.values[measure.title]
+					if measure.fn == ExcelAggregateCount {
+						cells = append(cells, newExcelCellByValue(value))
+						continue
+					}
+					// 明细行写入数字单元格，以便公式汇总
+					number, ok, err := aggregateNumber(value)
+					if err != nil {
+						return nil, NewCellError(record.rowNumber, indexOfString(aggregate.titles, measure.title)+1, measure.title, fmt.Sprint(value), err)
+					}
+					if ok {
+						cells = append(cells, NewExcelCellFloat64(number))
+					} else {
+						cells = append(cells, NewExcelCellAny(""))
+					}
+				}
+				addRow(cells, nil)
+			}
+			ranges = append(ranges, excelAggregateRange{start: start, end: rowNumber - 1})
+		default:
+			for _, child := range group.children {
+				childRanges, err := writeGroup(child)
+				if err != nil {
+					return nil, err
+				}
+				for _, childRange := range childRanges {
+					if last := len(ranges) - 1; last >= 0 && ranges[last].end+1 == childRange.start {
+						ranges[last].end = childRange.end
+					} else {
+						ranges = append(ranges, childRange)
+					}
+				}
+			}
+		}
+
+		if group.depth > 0 && aggregate.subtotal && len(ranges) > 0 {
+			if err := totalRow(group, ranges, aggregate.subtotalLabel, aggregate.subtotalStyler); err != nil {
+				return nil, err
+			}
+		}
+		return ranges, nil
+	}
+
+	root := aggregate.group()
+	ranges, err := writeGroup(root)
+	if err != nil {
+		return r.fail(err)
+	}
+	if aggregate.grandTotal && len(ranges) > 0 {
+		if err = totalRow(root, ranges, aggregate.grandTotalLabel, aggregate.grandTotalStyler); err != nil {
+			return r.fail(err)
+		}
+	}
+
+	return r
+}
+
+// aggregateFormula 生成汇总公式（如：SUM(C2:C5,C7:C9)，参数超过上限时嵌套拆分；不能用公式汇总时返回空）
+func aggregateFormula(fn ExcelAggregateFunc, detail bool, col int, ranges []excelAggregateRange) string {
+	refs := make([]string, len(ranges))
+	for idx, item := range ranges {
+		refs[idx] = NewExcelRangeRef(NewExcelCellRef(col, item.start), NewExcelCellRef(col, item.end)).String()
+	}
+
+	name := excelAggregateFormulas[fn][1]
+	if detail {
+		name = excelAggregateFormulas[fn][0]
+	}
+	switch {
+	case name == "":
+		return ""
+	case name == "AVERAGE" && len(refs) > excelFormulaMaxArgs:
+		return excelFormulaCall("SUM", "SUM", refs) + "/" + excelFormulaCall("COUNT", "SUM", refs)
+	case name == "COUNTA":
+		return excelFormulaCall(name, "SUM", refs)
+	default:
+		return excelFormulaCall(name, name, refs)
+	}
+}
+
+// excelFormulaCall 生成函数调用，参数超过上限时按上限拆分后以combine函数合并
+func excelFormulaCall(name, combine string, args []string) string {
+	if len(args) <= excelFormulaMaxArgs {
+		return name + "(" + strings.Join(args, ",") + ")"
+	}
+
+	var parts []string
+	for start := 0; start < len(args); start += excelFormulaMaxArgs {
+		parts = append(parts, excelFormulaCall(name, combine, args[start:minInt(start+excelFormulaMaxArgs, len(args))]))
+	}
+	return excelFormulaCall(combine, combine, parts)
+}
+
+// aggregateNumber 转换为数字（空值返回false，字符串忽略千分位逗号）
+func aggregateNumber(value any) (float64, bool, error) {
+	if isAggregateEmpty(value) {
+		return 0, false, nil
+	}
+
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true, nil
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(v.Float()) || math.IsInf(v.Float(), 0) {
+			return 0, false, nil
+		}
+		return v.Float(), true, nil
+	case reflect.String:
+		number, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(v.String()), ",", ""), 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("%w：不是数字", ErrCellContentInvalid)
+		}
+		return number, true, nil
+	default:
+		return 0, false, fmt.Errorf("%w：不是数字", ErrCellContentInvalid)
+	}
+}
+
+// aggregateFieldValue 结构体字段值（指针取指向的值，空指针为空字符串），使分组及小计名称按值而非指针地址
+func aggregateFieldValue(field reflect.Value) any {
+	for field.Kind() == reflect.Pointer || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return ""
+		}
+		field = field.Elem()
+	}
+	return field.Interface()
+}
+
+// isAggregateEmpty 是否为空值（nil、空指针、空白字符串）
+func isAggregateEmpty(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
+	return v.Kind() == reflect.String && strings.TrimSpace(v.String()) == ""
+}
+
+// indexOfString 字符串在切片中的位置（不存在时为-1）
+func indexOfString(items []string, item string) int {
+	for idx, v := range items {
+		if v == item {
+			return idx
+		}
+	}
+	return -1
+}
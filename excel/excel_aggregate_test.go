@@ -0,0 +1,130 @@
+package excel
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type aggregateSale struct {
+	Region  string  `excel:"地区"`
+	Product string  `excel:"产品"`
+	Amount  float64 `excel:"金额"`
+}
+
+type aggregateText struct {
+	Region string `excel:"地区"`
+	Amount string `excel:"金额"`
+}
+
+var aggregateSales = []aggregateSale{
+	{Region: "华东", Product: "A", Amount: 10},
+	{Region: "华东", Product: "B", Amount: 20},
+	{Region: "华北", Product: "A", Amount: 5},
+	{Region: "华东", Product: "A", Amount: 30},
+}
+
+// checkAggregateFile 写入后重新打开，want中"="开头的为公式，其他为单元格文本
+func checkAggregateFile(t *testing.T, write func(writer *ExcelWriter) *ExcelWriter, want map[string]string) *excelize.File {
+	t.Helper()
+
+	filename := filepath.Join(t.TempDir(), "aggregate.xlsx")
+	writer := write(NewExcelWriter(filename).SetCollectErr(true).ActiveSheetByIndex(0))
+	if err := writer.Err(); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := writer.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	f, err := excelize.OpenFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = f.Close() })
+
+	for coordinate, value := range want {
+		var got string
+		if value != "" && value[0] == '=' {
+			got, err = f.GetCellFormula("Sheet1", coordinate)
+			got = "=" + got
+		} else {
+			got, err = f.GetCellValue("Sheet1", coordinate)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != value {
+			t.Errorf("%s: got %q, want %q", coordinate, got, value)
+		}
+	}
+	return f
+}
+
+func TestWriteAggregateSummary(t *testing.T) {
+	aggregate := NewExcelAggregateByStructs(aggregateSales).GroupBy("地区", "产品").Sum("金额").Count("金额").Max("金额")
+	f := checkAggregateFile(t, func(writer *ExcelWriter) *ExcelWriter { return writer.WriteAggregate(aggregate) }, map[string]string{
+		"A1": "地区", "C1": "金额", "D1": "金额（计数）", "E1": "金额（最大值）",
+		"A2": "华东", "B2": "A", "C2": "40", "D2": "2", "E2": "30",
+		"A3": "华东", "B3": "B", "C3": "20", "D3": "1", "E3": "20",
+		"A4": "华东 小计", "C4": "=SUM(C2:C3)", "D4": "=SUM(D2:D3)", "E4": "=MAX(E2:E3)",
+		"A5": "华北", "B5": "A", "C5": "5", "D5": "1", "E5": "5",
+		"A6": "华北 小计", "C6": "=SUM(C5)", "D6": "=SUM(D5)", "E6": "=MAX(E5)",
+		"A7": "合计", "C7": "=SUM(C2:C3,C5)", "D7": "=SUM(D2:D3,D5)", "E7": "=MAX(E2:E3,E5)",
+	})
+
+	for coordinate, want := range map[string]string{"C7": "65", "D7": "4", "E7": "30"} {
+		if got, err := f.CalcCellValue("Sheet1", coordinate); err != nil || got != want {
+			t.Errorf("CalcCellValue(%s): got %q, %v, want %s", coordinate, got, err, want)
+		}
+	}
+}
+
+func TestWriteAggregateDetail(t *testing.T) {
+	aggregate := NewExcelAggregateByStructs(aggregateSales).GroupBy("地区").Sum("金额").Count("金额").Max("金额").SetDetail(true)
+	f := checkAggregateFile(t, func(writer *ExcelWriter) *ExcelWriter { return writer.WriteAggregate(aggregate) }, map[string]string{
+		"A2": "华东", "B2": "10", "B3": "20", "B4": "30",
+		"A5": "华东 小计", "B5": "=SUM(B2:B4)", "C5": "=COUNTA(C2:C4)", "D5": "=MAX(D2:D4)",
+		"A6": "华北", "B6": "5",
+		"A7": "华北 小计", "B7": "=SUM(B6)", "C7": "=COUNTA(C6)", "D7": "=MAX(D6)",
+		"A8": "合计", "B8": "=SUM(B2:B4,B6)", "C8": "=COUNTA(C2:C4,C6)", "D8": "=MAX(D2:D4,D6)",
+	})
+
+	for coordinate, want := range map[string]string{"B8": "65", "C8": "4", "D8": "30"} {
+		if got, err := f.CalcCellValue("Sheet1", coordinate); err != nil || got != want {
+			t.Errorf("CalcCellValue(%s): got %q, %v, want %s", coordinate, got, err, want)
+		}
+	}
+}
+
+func TestWriteAggregateDetailError(t *testing.T) {
+	data := []aggregateText{{Region: "华东", Amount: "1"}, {Region: "华东", Amount: "abc"}}
+	aggregate := NewExcelAggregateByStructs(data).GroupBy("地区").Sum("金额").SetDetail(true)
+	writer := NewExcelWriter(filepath.Join(t.TempDir(), "aggregate.xlsx")).SetCollectErr(true).ActiveSheetByIndex(0).WriteAggregate(aggregate)
+
+	var cellErr *CellError
+	if !errors.As(writer.Err(), &cellErr) {
+		t.Fatalf("WriteAggregate: got %v, want CellError", writer.Err())
+	}
+	if cellErr.RowNumber != 2 || cellErr.ColumnNumber != 2 {
+		t.Errorf("CellError: got row %d col %d, want source row 2 col 2", cellErr.RowNumber, cellErr.ColumnNumber)
+	}
+}
+
+func TestWritePivot(t *testing.T) {
+	pivot := NewExcelPivotByStructs(aggregateSales).SetRows("地区").SetColumn("产品").SetMeasure("金额", ExcelAggregateSum)
+	f := checkAggregateFile(t, func(writer *ExcelWriter) *ExcelWriter { return writer.WritePivot(pivot) }, map[string]string{
+		"A1": "地区", "B1": "A", "C1": "B", "D1": "合计",
+		"A2": "华东", "B2": "40", "C2": "20", "D2": "=SUM(B2:C2)",
+		"A3": "华北", "B3": "5", "C3": "", "D3": "=SUM(B3:C3)",
+		"A4": "合计", "B4": "=SUM(B2:B3)", "C4": "=SUM(C2:C3)", "D4": "=SUM(D2:D3)",
+	})
+
+	for coordinate, want := range map[string]string{"D2": "60", "D3": "5", "B4": "45", "D4": "65"} {
+		if got, err := f.CalcCellValue("Sheet1", coordinate); err != nil || got != want {
+			t.Errorf("CalcCellValue(%s): got %q, %v, want %s", coordinate, got, err, want)
+		}
+	}
+}
@@ -1,30 +1,103 @@
 package excel
 
+import (
+	"time"
+)
+
 type (
 	// ExcelCellContentType 单元格内容类型
 	ExcelCellContentType string
 
+	// ExcelCellBorder 单元格边框
+	ExcelCellBorder struct {
+		Side  string
+		Style int
+		Color string
+	}
+
 	// ExcelCell Excel单元格
 	ExcelCell struct {
-		content     any
-		contentType ExcelCellContentType
-		coordinate  string
-		fontColor   string
-		fontBold    bool
-		fontItalic  bool
-		fontFamily  string
-		fontSize    float64
+		content             any
+		contentType         ExcelCellContentType
+		coordinate          string
+		fontColor           string
+		fontBold            bool
+		fontItalic          bool
+		fontFamily          string
+		fontSize            float64
+		fontUnderline       string
+		fontStrike          bool
+		fillColor           string
+		borders             []ExcelCellBorder
+		horizontalAlignment string
+		verticalAlignment   string
+		wrapText            bool
+		indent              int
+		numberFormat        string
+		unlocked            bool
+		hyperlink           string
+		hyperlinkType       string
+		commentAuthor       string
+		commentText         string
 	}
 )
 
 const (
-	ExcelCellContentTypeAny     ExcelCellContentType = "any"
-	ExcelCellContentTypeFormula ExcelCellContentType = "formula"
-	ExcelCellContentTypeInt     ExcelCellContentType = "int"
-	ExcelCellContentTypeFloat64 ExcelCellContentType = "float64"
-	ExcelCellContentTypeBool    ExcelCellContentType = "bool"
+	ExcelCellContentTypeAny      ExcelCellContentType = "any"
+	ExcelCellContentTypeFormula  ExcelCellContentType = "formula"
+	ExcelCellContentTypeInt      ExcelCellContentType = "int"
+	ExcelCellContentTypeFloat64  ExcelCellContentType = "float64"
+	ExcelCellContentTypeBool     ExcelCellContentType = "bool"
+	ExcelCellContentTypeDate     ExcelCellContentType = "date"
+	ExcelCellContentTypeDateTime ExcelCellContentType = "datetime"
+	ExcelCellContentTypeTime     ExcelCellContentType = "time"
+	ExcelCellContentTypeDuration ExcelCellContentType = "duration"
+	ExcelCellContentTypeImage    ExcelCellContentType = "image"
+	ExcelCellContentTypeRichText ExcelCellContentType = "richtext"
 )
 
+const (
+	ExcelBorderLeft   = "left"
+	ExcelBorderRight  = "right"
+	ExcelBorderTop    = "top"
+	ExcelBorderBottom = "bottom"
+
+	ExcelBorderStyleNone   = 0
+	ExcelBorderStyleThin   = 1
+	ExcelBorderStyleMedium = 2
+	ExcelBorderStyleDashed = 3
+	ExcelBorderStyleDotted = 4
+	ExcelBorderStyleThick  = 5
+	ExcelBorderStyleDouble = 6
+
+	ExcelHorizontalLeft        = "left"
+	ExcelHorizontalCenter      = "center"
+	ExcelHorizontalRight       = "right"
+	ExcelHorizontalJustify     = "justify"
+	ExcelHorizontalDistributed = "distributed"
+
+	ExcelVerticalTop    = "top"
+	ExcelVerticalCenter = "center"
+	ExcelVerticalBottom = "bottom"
+
+	ExcelUnderlineSingle = "single"
+	ExcelUnderlineDouble = "double"
+
+	ExcelNumberFormatThousands        = "#,##0"
+	ExcelNumberFormatThousandsDecimal = "#,##0.00"
+	ExcelNumberFormatPercent          = "0.00%"
+	ExcelNumberFormatCurrency         = "¥#,##0.00"
+	ExcelNumberFormatDate             = "yyyy-mm-dd"
+	ExcelNumberFormatDateTime         = "yyyy-mm-dd hh:mm:ss"
+	ExcelNumberFormatTime             = "hh:mm:ss"
+	ExcelNumberFormatDuration         = "[h]:mm:ss"
+
+	ExcelHyperlinkExternal = "External"
+	ExcelHyperlinkLocation = "Location"
+)
+
+var excelBorderSides = []string{ExcelBorderLeft, ExcelBorderRight, ExcelBorderTop, ExcelBorderBottom}
+
 // NewExcelCellAny 构造函数（字符串格式）
 func NewExcelCellAny(content any) *ExcelCell {
 	return &ExcelCell{content: content, contentType: ExcelCellContentTypeAny}
@@ -50,6 +123,31 @@ func NewExcelCellBool(content bool) *ExcelCell {
 	return &ExcelCell{content: content, contentType: ExcelCellContentTypeBool}
 }
 
+// NewExcelCellDate 构造函数（日期格式）
+func NewExcelCellDate(content time.Time) *ExcelCell {
+	return &ExcelCell{content: content, contentType: ExcelCellContentTypeDate}
+}
+
+// NewExcelCellDateTime 构造函数（日期时间格式）
+func NewExcelCellDateTime(content time.Time) *ExcelCell {
+	return &ExcelCell{content: content, contentType: ExcelCellContentTypeDateTime}
+}
+
+// NewExcelCellTime 构造函数（时间格式）
+func NewExcelCellTime(content time.Time) *ExcelCell {
+	return &ExcelCell{content: content, contentType: ExcelCellContentTypeTime}
+}
+
+// NewExcelCellDuration 构造函数（时长格式）
+func NewExcelCellDuration(content time.Duration) *ExcelCell {
+	return &ExcelCell{content: content, contentType: ExcelCellContentTypeDuration}
+}
+
+// NewExcelCellImage 构造函数（图片格式，支持png、jpeg、gif、tiff，图片大于单元格时按比例缩小）
+func NewExcelCellImage(content []byte) *ExcelCell {
+	return &ExcelCell{content: content, contentType: ExcelCellContentTypeImage}
+}
+
 // GetFontColor 获取字体颜色
 func (r *ExcelCell) GetFontColor() string {
 	return r.fontColor
@@ -113,6 +211,195 @@ func (r *ExcelCell) SetFontSize(fontSize float64) *ExcelCell {
 	return r
 }
 
+// GetFontUnderline 获取下划线
+func (r *ExcelCell) GetFontUnderline() string {
+	return r.fontUnderline
+}
+
+// SetFontUnderline 设置下划线（single、double）
+func (r *ExcelCell) SetFontUnderline(fontUnderline string, condition bool) *ExcelCell {
+	if condition {
+		r.fontUnderline = fontUnderline
+	}
+	return r
+}
+
+// GetFontStrike 获取删除线
+func (r *ExcelCell) GetFontStrike() bool {
+	return r.fontStrike
+}
+
+// SetFontStrike 设置删除线
+func (r *ExcelCell) SetFontStrike(fontStrike bool, condition bool) *ExcelCell {
+	if condition {
+		r.fontStrike = fontStrike
+	}
+	return r
+}
+
+// GetFillColor 获取填充颜色
+func (r *ExcelCell) GetFillColor() string {
+	return r.fillColor
+}
+
+// SetFillColor 设置填充颜色（如：#FFFF00）
+func (r *ExcelCell) SetFillColor(fillColor string, condition bool) *ExcelCell {
+	if condition {
+		r.fillColor = fillColor
+	}
+	return r
+}
+
+// GetBorders 获取边框
+func (r *ExcelCell) GetBorders() []ExcelCellBorder {
+	return r.borders
+}
+
+// SetBorder 设置四周边框
+func (r *ExcelCell) SetBorder(style int, color string, condition bool) *ExcelCell {
+	if condition {
+		for _, side := range excelBorderSides {
+			r.SetBorderSide(side, style, color, true)
+		}
+	}
+	return r
+}
+
+// SetBorderSide 设置单侧边框（left、right、top、bottom）
+func (r *ExcelCell) SetBorderSide(side string, style int, color string, condition bool) *ExcelCell {
+	if !condition {
+		return r
+	}
+
+	for idx, border := range r.borders {
+		if border.Side == side {
+			r.borders[idx] = ExcelCellBorder{Side: side, Style: style, Color: color}
+			return r
+		}
+	}
+	r.borders = append(r.borders, ExcelCellBorder{Side: side, Style: style, Color: color})
+
+	return r
+}
+
+// GetHorizontalAlignment 获取水平对齐
+func (r *ExcelCell) GetHorizontalAlignment() string {
+	return r.horizontalAlignment
+}
+
+// SetHorizontalAlignment 设置水平对齐（left、center、right、justify、distributed）
+func (r *ExcelCell) SetHorizontalAlignment(horizontalAlignment string, condition bool) *ExcelCell {
+	if condition {
+		r.horizontalAlignment = horizontalAlignment
+	}
+	return r
+}
+
+// GetVerticalAlignment 获取垂直对齐
+func (r *ExcelCell) GetVerticalAlignment() string {
+	return r.verticalAlignment
+}
+
+// SetVerticalAlignment 设置垂直对齐（top、center、bottom）
+func (r *ExcelCell) SetVerticalAlignment(verticalAlignment string, condition bool) *ExcelCell {
+	if condition {
+		r.verticalAlignment = verticalAlignment
+	}
+	return r
+}
+
+// GetWrapText 获取自动换行
+func (r *ExcelCell) GetWrapText() bool {
+	return r.wrapText
+}
+
+// SetWrapText 设置自动换行
+func (r *ExcelCell) SetWrapText(wrapText bool, condition bool) *ExcelCell {
+	if condition {
+		r.wrapText = wrapText
+	}
+	return r
+}
+
+// GetIndent 获取缩进
+func (r *ExcelCell) GetIndent() int {
+	return r.indent
+}
+
+// SetIndent 设置缩进
+func (r *ExcelCell) SetIndent(indent int) *ExcelCell {
+	r.indent = indent
+	return r
+}
+
+// GetNumberFormat 获取数字格式
+func (r *ExcelCell) GetNumberFormat() string {
+	return r.numberFormat
+}
+
+// SetNumberFormat 设置数字格式（自定义格式，如：#,##0.00）
+func (r *ExcelCell) SetNumberFormat(numberFormat string) *ExcelCell {
+	r.numberFormat = numberFormat
+	return r
+}
+
+// GetUnlocked 获取是否解除锁定
+func (r *ExcelCell) GetUnlocked() bool {
+	return r.unlocked
+}
+
+// SetUnlocked 设置解除锁定（工作表保护后仍可编辑，用于输入单元格）
+func (r *ExcelCell) SetUnlocked(unlocked bool, condition bool) *ExcelCell {
+	if condition {
+		r.unlocked = unlocked
+	}
+	return r
+}
+
+// GetHyperlink 获取超链接
+func (r *ExcelCell) GetHyperlink() string {
+	return r.hyperlink
+}
+
+// GetHyperlinkType 获取超链接类型
+func (r *ExcelCell) GetHyperlinkType() string {
+	return r.hyperlinkType
+}
+
+// SetHyperlink 设置外部超链接（如：https://example.com，单元格内容为显示文字）
+func (r *ExcelCell) SetHyperlink(link string, condition bool) *ExcelCell {
+	if condition {
+		r.hyperlink, r.hyperlinkType = link, ExcelHyperlinkExternal
+	}
+	return r
+}
+
+// SetLocationLink 设置工作簿内部超链接（如：Sheet2!A1）
+func (r *ExcelCell) SetLocationLink(location string, condition bool) *ExcelCell {
+	if condition {
+		r.hyperlink, r.hyperlinkType = location, ExcelHyperlinkLocation
+	}
+	return r
+}
+
+// GetCommentAuthor 获取批注作者
+func (r *ExcelCell) GetCommentAuthor() string {
+	return r.commentAuthor
+}
+
+// GetCommentText 获取批注内容
+func (r *ExcelCell) GetCommentText() string {
+	return r.commentText
+}
+
+// SetComment 设置批注
+func (r *ExcelCell) SetComment(author, text string, condition bool) *ExcelCell {
+	if condition {
+		r.commentAuthor, r.commentText = author, text
+	}
+	return r
+}
+
 // Init 初始化
 func (r *ExcelCell) Init(content any) *ExcelCell {
 	r.content = content
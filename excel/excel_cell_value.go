@@ -0,0 +1,141 @@
+package excel
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// ExcelCellValueType 读取到的单元格值类型
+	ExcelCellValueType string
+
+	// ExcelCellValue 读取到的单元格（带类型的值、原始值、数字格式及公式）
+	ExcelCellValue struct {
+		coordinate   string
+		title        string
+		valueType    ExcelCellValueType
+		resultType   ExcelCellValueType
+		value        any
+		raw          string
+		text         string
+		numberFormat string
+		formula      string
+	}
+)
+
+const (
+	ExcelCellValueEmpty   ExcelCellValueType = "empty"
+	ExcelCellValueNumber  ExcelCellValueType = "number"
+	ExcelCellValueString  ExcelCellValueType = "string"
+	ExcelCellValueBool    ExcelCellValueType = "bool"
+	ExcelCellValueDate    ExcelCellValueType = "date"
+	ExcelCellValueFormula ExcelCellValueType = "formula"
+	ExcelCellValueError   ExcelCellValueType = "error"
+)
+
+// excelErrorValues Excel错误值
+var excelErrorValues = map[string]bool{"#NULL!": true, "#DIV/0!": true, "#VALUE!": true, "#REF!": true, "#NAME?": true, "#NUM!": true, "#N/A": true, "#GETTING_DATA": true}
+
+// GetCoordinate 获取坐标
+func (r *ExcelCellValue) GetCoordinate() string {
+	return r.coordinate
+}
+
+// GetTitle 获取所在列的表头
+func (r *ExcelCellValue) GetTitle() string {
+	return r.title
+}
+
+// GetType 获取值类型（公式单元格为ExcelCellValueFormula，计算结果的类型通过GetResultType获取）
+func (r *ExcelCellValue) GetType() ExcelCellValueType {
+	return r.valueType
+}
+
+// GetResultType 获取值的实际类型（公式单元格为计算结果的类型，其余与GetType相同）
+func (r *ExcelCellValue) GetResultType() ExcelCellValueType {
+	return r.resultType
+}
+
+// GetValue 获取值（数字为float64，布尔为bool，日期为time.Time，字符串及错误为string，空单元格为nil）
+func (r *ExcelCellValue) GetValue() any {
+	return r.value
+}
+
+// GetRaw 获取原始值（未应用数字格式，公式单元格为缓存的计算结果）
+func (r *ExcelCellValue) GetRaw() string {
+	return r.raw
+}
+
+// GetText 获取显示文本（应用数字格式后的内容，与Read读取到的内容相同）
+func (r *ExcelCellValue) GetText() string {
+	return r.text
+}
+
+// GetNumberFormat 获取数字格式（如：#,##0.00、yyyy-mm-dd）
+func (r *ExcelCellValue) GetNumberFormat() string {
+	return r.numberFormat
+}
+
+// GetFormula 获取公式（不含等号，非公式单元格为空）
+func (r *ExcelCellValue) GetFormula() string {
+	return r.formula
+}
+
+// IsEmpty 是否为空单元格
+func (r *ExcelCellValue) IsEmpty() bool {
+	return r.resultType == ExcelCellValueEmpty
+}
+
+// GetFloat64 获取数字值
+func (r *ExcelCellValue) GetFloat64() (float64, bool) {
+	value, ok := r.value.(float64)
+	return value, ok
+}
+
+// GetBool 获取布尔值
+func (r *ExcelCellValue) GetBool() (bool, bool) {
+	value, ok := r.value.(bool)
+	return value, ok
+}
+
+// GetTime 获取日期值
+func (r *ExcelCellValue) GetTime() (time.Time, bool) {
+	value, ok := r.value.(time.Time)
+	return value, ok
+}
+
+// String 实现fmt.Stringer接口
+func (r *ExcelCellValue) String() string {
+	return r.text
+}
+
+// setResult 根据计算结果（如CalcCellValue的返回值）推断值及类型
+func (r *ExcelCellValue) setResult(result string, isDate bool) {
+	switch upper := strings.ToUpper(result); {
+	case result == "":
+		r.resultType, r.value = ExcelCellValueEmpty, nil
+	case upper == "TRUE" || upper == "FALSE":
+		r.resultType, r.value = ExcelCellValueBool, upper == "TRUE"
+	case excelErrorValues[upper]:
+		r.resultType, r.value = ExcelCellValueError, result
+	default:
+		r.setNumber(result, isDate)
+	}
+}
+
+// setNumber 数字（日期格式时转为时间），无法转换时按字符串处理
+func (r *ExcelCellValue) setNumber(raw string, isDate bool) {
+	number, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		r.resultType, r.value = ExcelCellValueString, raw
+		return
+	}
+	if isDate {
+		if t, err := ExcelSerialToTime(number); err == nil {
+			r.resultType, r.value = ExcelCellValueDate, t
+			return
+		}
+	}
+	r.resultType, r.value = ExcelCellValueNumber, number
+}
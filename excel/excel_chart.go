@@ -0,0 +1,251 @@
+package excel
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type (
+	// ExcelChartType 图表类型
+	ExcelChartType excelize.ChartType
+
+	// ExcelChartSeries 图表数据系列（引用须带工作表名称，如：Sheet1!$B$1、Sheet1!$A$2:$A$10）
+	ExcelChartSeries struct {
+		name       string
+		categories string
+		values     string
+	}
+
+	// ExcelChart 图表
+	ExcelChart struct {
+		chartType      ExcelChartType
+		series         []*ExcelChartSeries
+		title          string
+		xAxisTitle     string
+		yAxisTitle     string
+		legendPosition string
+		width          int
+		height         int
+		combos         []*ExcelChart
+	}
+)
+
+const (
+	ExcelChartColumn  ExcelChartType = ExcelChartType(excelize.Col)
+	ExcelChartBar     ExcelChartType = ExcelChartType(excelize.Bar)
+	ExcelChartLine    ExcelChartType = ExcelChartType(excelize.Line)
+	ExcelChartPie     ExcelChartType = ExcelChartType(excelize.Pie)
+	ExcelChartScatter ExcelChartType = ExcelChartType(excelize.Scatter)
+	ExcelChartArea    ExcelChartType = ExcelChartType(excelize.Area)
+
+	ExcelLegendNone   = "none"
+	ExcelLegendTop    = "top"
+	ExcelLegendBottom = "bottom"
+	ExcelLegendLeft   = "left"
+	ExcelLegendRight  = "right"
+)
+
+// NewExcelChartSeries 构造函数（categories为分类或散点图的X值，values为数值）
+func NewExcelChartSeries(name, categories, values string) *ExcelChartSeries {
+	return &ExcelChartSeries{name: name, categories: categories, values: values}
+}
+
+// NewExcelChart 构造函数（默认图例在底部，尺寸480×290）
+func NewExcelChart(chartType ExcelChartType) *ExcelChart {
+	return &ExcelChart{chartType: chartType, legendPosition: ExcelLegendBottom, width: 480, height: 290}
+}
+
+// GetType 获取图表类型
+func (r *ExcelChart) GetType() ExcelChartType {
+	return r.chartType
+}
+
+// AddSeries 增加数据系列
+func (r *ExcelChart) AddSeries(series ...*ExcelChartSeries) *ExcelChart {
+	r.series = append(r.series, series...)
+	return r
+}
+
+// GetSeries 获取数据系列
+func (r *ExcelChart) GetSeries() []*ExcelChartSeries {
+	return r.series
+}
+
+// SetTitle 设置标题
+func (r *ExcelChart) SetTitle(title string) *ExcelChart {
+	r.title = title
+	return r
+}
+
+// SetXAxisTitle 设置横轴（分类轴）标题
+func (r *ExcelChart) SetXAxisTitle(title string) *ExcelChart {
+	r.xAxisTitle = title
+	return r
+}
+
+// SetYAxisTitle 设置纵轴（数值轴）标题
+func (r *ExcelChart) SetYAxisTitle(title string) *ExcelChart {
+	r.yAxisTitle = title
+	return r
+}
+
+// SetLegend 设置图例位置（ExcelLegendNone为不显示）
+func (r *ExcelChart) SetLegend(position string) *ExcelChart {
+	r.legendPosition = position
+	return r
+}
+
+// SetSize 设置尺寸（像素）
+func (r *ExcelChart) SetSize(width, height int) *ExcelChart {
+	r.width, r.height = width, height
+	return r
+}
+
+// AddCombo 增加组合图表（与主图表共用绘图区，如：柱形图+折线图）
+func (r *ExcelChart) AddCombo(chart *ExcelChart) *ExcelChart {
+	r.combos = append(r.combos, chart)
+	return r
+}
+
+// clone 复制图表（数据系列、组合图表单独复制，修改副本不影响原图表）
+func (r *ExcelChart) clone() *ExcelChart {
+	chart := *r
+	chart.series = append([]*ExcelChartSeries{}, r.series...)
+	chart.combos = append([]*ExcelChart{}, r.combos...)
+	return &chart
+}
+
+// format 生成excelize图表格式
+func (r *ExcelChart) format() *excelize.Chart {
+	var (
+		series      = make([]excelize.ChartSeries, len(r.series))
+		printObject = true
+		varyColors  = r.chartType == ExcelChartPie
+	)
+	for idx, s := range r.series {
+		series[idx] = excelize.ChartSeries{Name: s.name, Categories: s.categories, Values: s.values}
+	}
+	return &excelize.Chart{
+		Type:       excelize.ChartType(r.chartType),
+		Series:     series,
+		Dimension:  excelize.ChartDimension{Width: uint(r.width), Height: uint(r.height)},
+		Format:     excelize.GraphicOptions{ScaleX: 1.0, ScaleY: 1.0, PrintObject: &printObject},
+		Legend:     excelize.ChartLegend{Position: r.legendPosition},
+		Title:      chartTitle(r.title),
+		VaryColors: &varyColors,
+		PlotArea:   excelize.ChartPlotArea{ShowPercent: r.chartType == ExcelChartPie},
+		XAxis:      excelize.ChartAxis{Title: chartTitle(r.xAxisTitle)},
+		YAxis:      excelize.ChartAxis{Title: chartTitle(r.yAxisTitle)},
+	}
+}
+
+// chartTitle 图表及坐标轴标题（为空时不显示）
+func chartTitle(title string) []excelize.RichTextRun {
+	if title == "" {
+		return nil
+	}
+	return []excelize.RichTextRun{{Text: title}}
+}
+
+// AddChart 在指定单元格处（图表左上角）添加图表
+func (r *ExcelWriter) AddChart(cell string, chart *ExcelChart) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if r.stream != nil {
+		return r.fail(ErrStreamUnsupported)
+	}
+	if len(chart.series) == 0 {
+		return r.fail(fmt.Errorf("添加图表错误（%s）：未设置数据系列", cell))
+	}
+
+	combos := make([]*excelize.Chart, len(chart.combos))
+	for idx, combo := range chart.combos {
+		combos[idx] = combo.format()
+	}
+
+	if err := r.excel.AddChart(r.sheetName, cell, chart.format(), combos...); err != nil {
+		return r.fail(fmt.Errorf("添加图表错误（%s）：%w", cell, err))
+	}
+
+	return r
+}
+
+// AddChartByTitles 根据表头名称添加图表（数据为SetTitleRow之后通过AddRow写入的行，categoryTitle为分类列，valueTitles为数值列；
+// 数据系列添加到chart的副本上，chart可重复用于其他工作表）
+//
+//	writer.AddChartByTitles("F2", NewExcelChart(ExcelChartColumn).SetTitle("月度销售"), "月份", "销售额", "成本")
+func (r *ExcelWriter) AddChartByTitles(cell string, chart *ExcelChart, categoryTitle string, valueTitles ...string) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+
+	categories, err := r.GetColumnRange(categoryTitle)
+	if err != nil {
+		return r.fail(fmt.Errorf("添加图表错误（%s）：%w", cell, err))
+	}
+	chart = chart.clone()
+	for _, title := range valueTitles {
+		name, err := r.GetTitleCell(title)
+		if err != nil {
+			return r.fail(fmt.Errorf("添加图表错误（%s）：%w", cell, err))
+		}
+		values, err := r.GetColumnRange(title)
+		if err != nil {
+			return r.fail(fmt.Errorf("添加图表错误（%s）：%w", cell, err))
+		}
+		chart.AddSeries(NewExcelChartSeries(name, categories, values))
+	}
+
+	return r.AddChart(cell, chart)
+}
+
+// GetTitleCell 获取当前工作表表头单元格的引用（如：Sheet1!$B$1）
+func (r *ExcelWriter) GetTitleCell(title string) (string, error) {
+	col, _, err := r.findTitle(title)
+	if err != nil {
+		return "", err
+	}
+
+	return NewExcelCellRef(col, int(r.titleRows[r.sheetName])).Absolute().WithSheet(r.sheetName).String(), nil
+}
+
+// GetColumnRange 获取当前工作表表头下方数据区域的引用（如：Sheet1!$B$2:$B$10）
+func (r *ExcelWriter) GetColumnRange(title string) (string, error) {
+	col, lastRowNumber, err := r.findTitle(title)
+	if err != nil {
+		return "", err
+	}
+	if lastRowNumber <= int(r.titleRows[r.sheetName]) {
+		return "", fmt.Errorf("表头下方没有数据：%s", title)
+	}
+
+	return NewExcelRangeRef(NewExcelCellRef(col, int(r.titleRows[r.sheetName])+1), NewExcelCellRef(col, lastRowNumber)).Absolute().WithSheet(r.sheetName).String(), nil
+}
+
+// findTitle 查找表头所在列及最后一行行号
+func (r *ExcelWriter) findTitle(title string) (int, int, error) {
+	titleRowNumber, exist := r.titleRows[r.sheetName]
+	if !exist {
+		return 0, 0, ErrTitleNotSet
+	}
+	if r.stream != nil {
+		return 0, 0, ErrStreamUnsupported
+	}
+
+	rows, err := r.excel.GetRows(r.sheetName)
+	if err != nil {
+		return 0, 0, fmt.Errorf("读取数据错误：%w", err)
+	}
+	if int(titleRowNumber) > len(rows) {
+		return 0, 0, fmt.Errorf("%w：第%d行", ErrTitleRowMissing, titleRowNumber)
+	}
+	for idx, content := range rows[titleRowNumber-1] {
+		if content == title {
+			return idx + 1, len(rows), nil
+		}
+	}
+
+	return 0, 0, fmt.Errorf("表头不存在：%s", title)
+}
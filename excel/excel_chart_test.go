@@ -0,0 +1,31 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestAddChartByTitlesKeepsChart(t *testing.T) {
+	chart := NewExcelChart(ExcelChartColumn)
+	writer := NewExcelWriter(filepath.Join(t.TempDir(), "chart.xlsx")).SetCollectErr(true).ActiveSheetByIndex(0).
+		SetTitleRow([]string{"月份", "销售额"}, 1).
+		AddRow(NewExcelRow().SetRowNumber(2).SetCells([]*ExcelCell{NewExcelCellAny("1月"), NewExcelCellInt(10)})).
+		AddChartByTitles("D2", chart, "月份", "销售额").
+		AddChartByTitles("D20", chart, "月份", "销售额")
+	if err := writer.Err(); err != nil {
+		t.Fatalf("AddChartByTitles: %v", err)
+	}
+	if got := len(chart.GetSeries()); got != 0 {
+		t.Errorf("chart series: got %d, want 0", got)
+	}
+}
+
+func TestChartFormatEmptyTitle(t *testing.T) {
+	format := NewExcelChart(ExcelChartLine).format()
+	if format.Title != nil || format.XAxis.Title != nil || format.YAxis.Title != nil {
+		t.Errorf("format: got title %v, x %v, y %v, want nil", format.Title, format.XAxis.Title, format.YAxis.Title)
+	}
+	if format = NewExcelChart(ExcelChartLine).SetTitle("销售").format(); len(format.Title) != 1 || format.Title[0].Text != "销售" {
+		t.Errorf("format: got title %v, want 销售", format.Title)
+	}
+}
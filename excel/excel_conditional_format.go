@@ -0,0 +1,246 @@
+package excel
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type (
+	// ExcelConditionalFormatType 条件格式类型
+	ExcelConditionalFormatType string
+
+	// ExcelConditionalFormat 条件格式（由Excel根据单元格值实时计算，修改数据后格式随之变化）
+	ExcelConditionalFormat struct {
+		formatType ExcelConditionalFormatType
+		criteria   string
+		value      string
+		minimum    string
+		maximum    string
+		percent    bool
+		style      *ExcelCell
+		minColor   string
+		midColor   string
+		maxColor   string
+		iconSet    string
+		reverse    bool
+	}
+)
+
+const (
+	ExcelConditionalFormatCell       ExcelConditionalFormatType = "cell"
+	ExcelConditionalFormatFormula    ExcelConditionalFormatType = "formula"
+	ExcelConditionalFormatTop        ExcelConditionalFormatType = "top"
+	ExcelConditionalFormatBottom     ExcelConditionalFormatType = "bottom"
+	ExcelConditionalFormatDuplicate  ExcelConditionalFormatType = "duplicate"
+	ExcelConditionalFormatUnique     ExcelConditionalFormatType = "unique"
+	ExcelConditionalFormatColorScale ExcelConditionalFormatType = "color_scale"
+	ExcelConditionalFormatDataBar    ExcelConditionalFormatType = "data_bar"
+	ExcelConditionalFormatIconSet    ExcelConditionalFormatType = "icon_set"
+
+	ExcelIconSet3Arrows        = "3Arrows"
+	ExcelIconSet3ArrowsGray    = "3ArrowsGray"
+	ExcelIconSet3Flags         = "3Flags"
+	ExcelIconSet3TrafficLights = "3TrafficLights1"
+	ExcelIconSet3Signs         = "3Signs"
+	ExcelIconSet3Symbols       = "3Symbols"
+	ExcelIconSet4Arrows        = "4Arrows"
+	ExcelIconSet4Rating        = "4Rating"
+	ExcelIconSet5Arrows        = "5Arrows"
+	ExcelIconSet5Rating        = "5Rating"
+	ExcelIconSet5Quarters      = "5Quarters"
+)
+
+// excelIconSetCounts excelize支持的图标集及图标数量
+var excelIconSetCounts = map[string]int{
+	"3Arrows": 3, "3ArrowsGray": 3, "3Flags": 3, "3Signs": 3, "3Symbols": 3, "3Symbols2": 3, "3TrafficLights1": 3, "3TrafficLights2": 3,
+	"4Arrows": 4, "4ArrowsGray": 4, "4Rating": 4, "4RedToBlack": 4, "4TrafficLights": 4,
+	"5Arrows": 5, "5ArrowsGray": 5, "5Quarters": 5, "5Rating": 5,
+}
+
+// NewExcelCellValueFormat 单元格值比较（criteria：>、>=、<、<=、=、!=），满足条件时应用style中的字体、填充及边框
+//
+//	NewExcelCellValueFormat("<", 0, NewExcelCellAny(nil).SetFontColor("#9C0006", true).SetFillColor("#FFC7CE", true))
+func NewExcelCellValueFormat(criteria string, value any, style *ExcelCell) *ExcelConditionalFormat {
+	return &ExcelConditionalFormat{formatType: ExcelConditionalFormatCell, criteria: criteria, value: conditionalFormatValue(value), style: style}
+}
+
+// NewExcelCellBetweenFormat 单元格值介于minimum与maximum之间（含两端）
+func NewExcelCellBetweenFormat(minimum, maximum any, style *ExcelCell) *ExcelConditionalFormat {
+	return &ExcelConditionalFormat{formatType: ExcelConditionalFormatCell, criteria: "between", minimum: conditionalFormatValue(minimum), maximum: conditionalFormatValue(maximum), style: style}
+}
+
+// NewExcelFormulaFormat 公式（以区域左上角单元格为基准的相对引用，如：$C2>$D2）
+func NewExcelFormulaFormat(formula string, style *ExcelCell) *ExcelConditionalFormat {
+	return &ExcelConditionalFormat{formatType: ExcelConditionalFormatFormula, criteria: strings.TrimPrefix(formula, "="), style: style}
+}
+
+// NewExcelTopFormat 最大的前n项（percent为true时为前n%）
+func NewExcelTopFormat(n int, percent bool, style *ExcelCell) *ExcelConditionalFormat {
+	return &ExcelConditionalFormat{formatType: ExcelConditionalFormatTop, criteria: "=", value: strconv.Itoa(n), percent: percent, style: style}
+}
+
+// NewExcelBottomFormat 最小的后n项（percent为true时为后n%）
+func NewExcelBottomFormat(n int, percent bool, style *ExcelCell) *ExcelConditionalFormat {
+	return &ExcelConditionalFormat{formatType: ExcelConditionalFormatBottom, criteria: "=", value: strconv.Itoa(n), percent: percent, style: style}
+}
+
+// NewExcelDuplicateFormat 重复值
+func NewExcelDuplicateFormat(style *ExcelCell) *ExcelConditionalFormat {
+	return &ExcelConditionalFormat{formatType: ExcelConditionalFormatDuplicate, criteria: "=", style: style}
+}
+
+// NewExcelUniqueFormat 唯一值
+func NewExcelUniqueFormat(style *ExcelCell) *ExcelConditionalFormat {
+	return &ExcelConditionalFormat{formatType: ExcelConditionalFormatUnique, criteria: "=", style: style}
+}
+
+// NewExcelColorScaleFormat 双色刻度（最小值到最大值渐变）
+func NewExcelColorScaleFormat(minColor, maxColor string) *ExcelConditionalFormat {
+	return &ExcelConditionalFormat{formatType: ExcelConditionalFormatColorScale, criteria: "=", minColor: minColor, maxColor: maxColor}
+}
+
+// NewExcelColorScale3Format 三色刻度（中间值为50%分位）
+func NewExcelColorScale3Format(minColor, midColor, maxColor string) *ExcelConditionalFormat {
+	return &ExcelConditionalFormat{formatType: ExcelConditionalFormatColorScale, criteria: "=", minColor: minColor, midColor: midColor, maxColor: maxColor}
+}
+
+// NewExcelDataBarFormat 数据条
+func NewExcelDataBarFormat(barColor string) *ExcelConditionalFormat {
+	return &ExcelConditionalFormat{formatType: ExcelConditionalFormatDataBar, criteria: "=", minColor: barColor}
+}
+
+// NewExcelIconSetFormat 图标集（如：ExcelIconSet3TrafficLights，按百分比均分）
+//
+// excelize中图标数量相同的图标集共用同一份规则，同一工作簿中每种图标数量（3、4、5）只能设置一个图标集，重复设置时返回错误
+func NewExcelIconSetFormat(iconSet string) *ExcelConditionalFormat {
+	return &ExcelConditionalFormat{formatType: ExcelConditionalFormatIconSet, iconSet: iconSet}
+}
+
+// GetType 获取条件格式类型
+func (r *ExcelConditionalFormat) GetType() ExcelConditionalFormatType {
+	return r.formatType
+}
+
+// SetReverse 设置反转图标顺序（仅图标集）
+func (r *ExcelConditionalFormat) SetReverse(reverse bool) *ExcelConditionalFormat {
+	r.reverse = reverse
+	return r
+}
+
+// conditionalFormatValue 条件值转公式（字符串加引号，以=开头时视为公式）
+func conditionalFormatValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(v, "=") {
+			return strings.TrimPrefix(v, "=")
+		}
+		if _, err := strconv.ParseFloat(v, 64); err == nil {
+			return v
+		}
+		return strconv.Quote(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// AddConditionalFormat 在单元格区域上设置条件格式（如：B2、B100，多个条件格式按顺序依次设置优先级）
+func (r *ExcelWriter) AddConditionalFormat(hCell, vCell string, formats ...*ExcelConditionalFormat) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if r.stream != nil {
+		return r.fail(ErrStreamUnsupported)
+	}
+
+	area := hCell + ":" + vCell
+	var (
+		options  = make([]excelize.ConditionalFormatOptions, len(formats))
+		iconSets = make(map[int]string)
+	)
+	for idx, format := range formats {
+		option, err := r.conditionalFormatOptions(format)
+		if err != nil {
+			return r.fail(fmt.Errorf("设置条件格式错误（%s）：%w", area, err))
+		}
+		if format.formatType == ExcelConditionalFormatIconSet {
+			count := excelIconSetCounts[format.iconSet]
+			if exist, ok := r.iconSets[count]; ok {
+				return r.fail(fmt.Errorf("设置条件格式错误（%s）：同一工作簿中只能设置一个%d图标的图标集，已在%s设置", area, count, exist))
+			}
+			if _, ok := iconSets[count]; ok {
+				return r.fail(fmt.Errorf("设置条件格式错误（%s）：同一工作簿中只能设置一个%d图标的图标集", area, count))
+			}
+			iconSets[count] = r.sheetName + "!" + area
+		}
+		options[idx] = option
+	}
+	if err := r.excel.SetConditionalFormat(r.sheetName, area, options); err != nil {
+		return r.fail(fmt.Errorf("设置条件格式错误（%s）：%w", area, err))
+	}
+	for count, iconSetArea := range iconSets {
+		r.iconSets[count] = iconSetArea
+	}
+
+	return r
+}
+
+// conditionalFormatOptions 生成excelize条件格式
+func (r *ExcelWriter) conditionalFormatOptions(format *ExcelConditionalFormat) (excelize.ConditionalFormatOptions, error) {
+	option := excelize.ConditionalFormatOptions{Type: string(format.formatType), Criteria: format.criteria}
+
+	switch format.formatType {
+	case ExcelConditionalFormatCell:
+		option.Value, option.MinValue, option.MaxValue = format.value, format.minimum, format.maximum
+	case ExcelConditionalFormatTop, ExcelConditionalFormatBottom:
+		option.Value, option.Percent = format.value, format.percent
+	case ExcelConditionalFormatColorScale:
+		option.Type = "2_color_scale"
+		option.MinType, option.MaxType = "min", "max"
+		option.MinColor, option.MaxColor = format.minColor, format.maxColor
+		if format.midColor != "" {
+			option.Type = "3_color_scale"
+			option.MidType, option.MidValue, option.MidColor = "percentile", "50", format.midColor
+		}
+	case ExcelConditionalFormatDataBar:
+		option.MinType, option.MaxType, option.BarColor = "min", "max", format.minColor
+	case ExcelConditionalFormatIconSet:
+		if _, exist := excelIconSetCounts[format.iconSet]; !exist {
+			return option, fmt.Errorf("不支持的图标集：%s", format.iconSet)
+		}
+		option.IconStyle, option.ReverseIcons = format.iconSet, format.reverse
+	}
+
+	if format.style != nil {
+		styleID, err := r.getFormatStyleID(format.style)
+		if err != nil {
+			return option, err
+		}
+		option.Format = styleID
+	}
+
+	return option, nil
+}
+
+// getFormatStyleID 获取条件格式样式编号（相同样式只创建一次）
+func (r *ExcelWriter) getFormatStyleID(cell *ExcelCell) (int, error) {
+	excelStyle := newExcelizeStyle(cell)
+	key, err := json.Marshal(excelStyle)
+	if err != nil {
+		return 0, err
+	}
+	if styleID, exist := r.formatStyles[string(key)]; exist {
+		return styleID, nil
+	}
+
+	styleID, err := r.excel.NewConditionalStyle(excelStyle)
+	if err != nil {
+		return 0, err
+	}
+	r.formatStyles[string(key)] = styleID
+
+	return styleID, nil
+}
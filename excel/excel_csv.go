@@ -0,0 +1,308 @@
+package excel
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+const (
+	// ExcelCSVEncodingAuto 自动识别（读取时有BOM按BOM识别，否则非UTF-8内容按GB18030解码；写入时为带BOM的UTF-8）
+	ExcelCSVEncodingAuto    = ""
+	ExcelCSVEncodingUTF8    = "utf-8"
+	ExcelCSVEncodingUTF8BOM = "utf-8-bom"
+	ExcelCSVEncodingGBK     = "gbk"
+	ExcelCSVEncodingGB18030 = "gb18030"
+
+	// ExcelCSVSheetName CSV文件读取后对应的工作表名称
+	ExcelCSVSheetName = "Sheet1"
+
+	// excelCSVSniffLines 识别分隔符时检查的行数
+	excelCSVSniffLines = 10
+)
+
+var (
+	// excelCSVDelimiters 可自动识别的分隔符
+	excelCSVDelimiters = []rune{',', '\t', ';', '|'}
+	// excelCSVExtensions CSV文件扩展名对应的默认分隔符（0为自动识别）
+	excelCSVExtensions = map[string]rune{".csv": 0, ".tsv": '\t', ".tab": '\t'}
+)
+
+// IsCSVFile 是否为CSV/TSV文件（根据扩展名）
+func IsCSVFile(filename string) bool {
+	_, exist := excelCSVExtensions[strings.ToLower(filepath.Ext(filename))]
+	return exist
+}
+
+// GetCSVDelimiter 获取CSV分隔符
+func (r *ExcelReader) GetCSVDelimiter() rune {
+	return r.csvDelimiter
+}
+
+// SetCSVDelimiter 设置CSV分隔符（0为自动识别逗号、制表符、分号、竖线，须在打开文件前设置）
+func (r *ExcelReader) SetCSVDelimiter(delimiter rune) *ExcelReader {
+	r.csvDelimiter = delimiter
+	return r
+}
+
+// GetCSVEncoding 获取CSV编码
+func (r *ExcelReader) GetCSVEncoding() string {
+	return r.csvEncoding
+}
+
+// SetCSVEncoding 设置CSV编码（默认自动识别，须在打开文件前设置）
+func (r *ExcelReader) SetCSVEncoding(csvEncoding string) *ExcelReader {
+	r.csvEncoding = csvEncoding
+	return r
+}
+
+// OpenCSV 打开CSV/TSV文件（读取为名称为Sheet1的工作表，之后与xlsx文件的用法相同）
+func (r *ExcelReader) OpenCSV(filename string, more ...any) *ExcelReader {
+	filename = fmt.Sprintf(filename, more...)
+	if filename == "" {
+		return r.fail(ErrFilenameEmpty)
+	}
+
+	delimiter := r.csvDelimiter
+	if delimiter == 0 {
+		delimiter = excelCSVExtensions[strings.ToLower(filepath.Ext(filename))]
+	}
+
+	return r.open(func(...excelize.Options) (*excelize.File, error) {
+		f, err := os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = f.Close() }()
+		return newCSVFile(f, delimiter, r.csvEncoding)
+	}, true)
+}
+
+// OpenCSVReader 从io.Reader打开CSV/TSV（如上传文件流）
+func (r *ExcelReader) OpenCSVReader(reader io.Reader) *ExcelReader {
+	return r.open(func(...excelize.Options) (*excelize.File, error) {
+		return newCSVFile(reader, r.csvDelimiter, r.csvEncoding)
+	}, true)
+}
+
+// newCSVFile 解析CSV并写入内存中的工作簿
+func newCSVFile(reader io.Reader, delimiter rune, csvEncoding string) (*excelize.File, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if content, err = decodeCSV(content, csvEncoding); err != nil {
+		return nil, fmt.Errorf("CSV解码错误：%w", err)
+	}
+	if delimiter == 0 {
+		delimiter = sniffCSVDelimiter(content)
+	}
+
+	csvReader := csv.NewReader(bytes.NewReader(content))
+	csvReader.Comma = delimiter
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+
+	f := excelize.NewFile()
+	for rowNumber := 1; ; rowNumber++ {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSV解析错误：%w", err)
+		}
+		if err = f.SetSheetRow(ExcelCSVSheetName, NewExcelCellRef(1, rowNumber).Coordinate(), &record); err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+// decodeCSV 转换为UTF-8（去除BOM）
+func decodeCSV(content []byte, csvEncoding string) ([]byte, error) {
+	var decoder *encoding.Decoder
+
+	switch {
+	case bytes.HasPrefix(content, []byte{0xEF, 0xBB, 0xBF}):
+		return content[3:], nil
+	case bytes.HasPrefix(content, []byte{0xFF, 0xFE}), bytes.HasPrefix(content, []byte{0xFE, 0xFF}):
+		decoder = unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM).NewDecoder()
+	case csvEncoding == ExcelCSVEncodingGBK:
+		decoder = simplifiedchinese.GBK.NewDecoder()
+	case csvEncoding == ExcelCSVEncodingGB18030, csvEncoding == ExcelCSVEncodingAuto && !utf8.Valid(content):
+		decoder = simplifiedchinese.GB18030.NewDecoder()
+	default:
+		return content, nil
+	}
+
+	return decoder.Bytes(content)
+}
+
+// sniffCSVDelimiter 识别分隔符（前若干行中出现次数一致且最多的分隔符，默认为逗号）
+func sniffCSVDelimiter(content []byte) rune {
+	var lines []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			lines = append(lines, line)
+		}
+		if len(lines) == excelCSVSniffLines {
+			break
+		}
+	}
+	if len(lines) == 0 {
+		return ','
+	}
+
+	delimiter, bestScore := ',', 0
+	for _, candidate := range excelCSVDelimiters {
+		count := countCSVDelimiter(lines[0], candidate)
+		if count == 0 {
+			continue
+		}
+		consistent := 0
+		for _, line := range lines {
+			if countCSVDelimiter(line, candidate) == count {
+				consistent++
+			}
+		}
+		if score := consistent*1000 + count; score > bestScore {
+			delimiter, bestScore = candidate, score
+		}
+	}
+
+	return delimiter
+}
+
+// countCSVDelimiter 统计引号外的分隔符数量
+func countCSVDelimiter(line string, delimiter rune) int {
+	var (
+		count   int
+		inQuote bool
+	)
+	for _, char := range line {
+		switch {
+		case char == '"':
+			inQuote = !inQuote
+		case char == delimiter && !inQuote:
+			count++
+		}
+	}
+	return count
+}
+
+// GetCSVDelimiter 获取CSV分隔符
+func (r *ExcelWriter) GetCSVDelimiter() rune {
+	return r.csvDelimiter
+}
+
+// SetCSVDelimiter 设置CSV分隔符（默认逗号，.tsv文件默认制表符）
+func (r *ExcelWriter) SetCSVDelimiter(delimiter rune) *ExcelWriter {
+	r.csvDelimiter = delimiter
+	return r
+}
+
+// GetCSVEncoding 获取CSV编码
+func (r *ExcelWriter) GetCSVEncoding() string {
+	return r.csvEncoding
+}
+
+// SetCSVEncoding 设置CSV编码（默认为带BOM的UTF-8，便于Excel直接打开）
+func (r *ExcelWriter) SetCSVEncoding(csvEncoding string) *ExcelWriter {
+	r.csvEncoding = csvEncoding
+	return r
+}
+
+// SaveCSV 将当前工作表保存为CSV/TSV文件（单元格按显示格式输出）
+func (r *ExcelWriter) SaveCSV(filename string, a ...any) error {
+	filename = fmt.Sprintf(filename, a...)
+	if filename == "" {
+		r.fail(ErrFilenameEmpty)
+		return ErrFilenameEmpty
+	}
+
+	var buffer bytes.Buffer
+	if _, err := r.writeCSV(&buffer, r.csvDelimiterFor(filename)); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, buffer.Bytes(), 0644)
+}
+
+// WriteCSVTo 将当前工作表以CSV格式写入io.Writer
+func (r *ExcelWriter) WriteCSVTo(w io.Writer) (int64, error) {
+	return r.writeCSV(w, r.csvDelimiterFor(""))
+}
+
+// csvDelimiterFor 获取写入分隔符
+func (r *ExcelWriter) csvDelimiterFor(filename string) rune {
+	if r.csvDelimiter != 0 {
+		return r.csvDelimiter
+	}
+	if delimiter := excelCSVExtensions[strings.ToLower(filepath.Ext(filename))]; delimiter != 0 {
+		return delimiter
+	}
+	return ','
+}
+
+// writeCSV 写入CSV
+func (r *ExcelWriter) writeCSV(w io.Writer, delimiter rune) (int64, error) {
+	if r.failed() {
+		return 0, r.err
+	}
+	if r.stream != nil {
+		if r.Flush(); r.failed() {
+			return 0, r.err
+		}
+	}
+
+	rows, err := r.excel.GetRows(r.sheetName)
+	if err != nil {
+		return 0, fmt.Errorf("读取数据错误：%w", err)
+	}
+
+	counter := &countWriter{writer: w}
+	var (
+		output  io.Writer = counter
+		encoder *encoding.Encoder
+	)
+	switch r.csvEncoding {
+	case ExcelCSVEncodingAuto, ExcelCSVEncodingUTF8BOM:
+		if _, err = counter.Write([]byte{0xEF, 0xBB, 0xBF}); err != nil {
+			return counter.count, err
+		}
+	case ExcelCSVEncodingGBK:
+		encoder = simplifiedchinese.GBK.NewEncoder()
+	case ExcelCSVEncodingGB18030:
+		encoder = simplifiedchinese.GB18030.NewEncoder()
+	}
+	if encoder != nil {
+		output = transform.NewWriter(counter, encoder)
+	}
+
+	csvWriter := csv.NewWriter(output)
+	csvWriter.Comma = delimiter
+	csvWriter.UseCRLF = true
+	if err = csvWriter.WriteAll(rows); err != nil {
+		return counter.count, fmt.Errorf("写入CSV错误：%w", err)
+	}
+	if closer, ok := output.(io.Closer); ok {
+		if err = closer.Close(); err != nil {
+			return counter.count, fmt.Errorf("写入CSV错误：%w", err)
+		}
+	}
+
+	return counter.count, nil
+}
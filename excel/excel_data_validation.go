@@ -0,0 +1,224 @@
+package excel
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type (
+	// ExcelDataValidationType 数据验证类型
+	ExcelDataValidationType string
+
+	// ExcelDataValidationErrorStyle 数据验证出错时的提示样式
+	ExcelDataValidationErrorStyle string
+
+	// ExcelDataValidation 数据验证（下拉列表、数值/日期范围，附带输入提示及出错提示）
+	ExcelDataValidation struct {
+		validationType ExcelDataValidationType
+		items          []string
+		lookupSheet    string
+		min            any
+		max            any
+		allowBlank     bool
+		promptTitle    string
+		prompt         string
+		errorStyle     ExcelDataValidationErrorStyle
+		errorTitle     string
+		errorMessage   string
+	}
+)
+
+const (
+	ExcelDataValidationTypeList    ExcelDataValidationType = "list"
+	ExcelDataValidationTypeInt     ExcelDataValidationType = "int"
+	ExcelDataValidationTypeDecimal ExcelDataValidationType = "decimal"
+	ExcelDataValidationTypeDate    ExcelDataValidationType = "date"
+
+	ExcelDataValidationErrorStop        ExcelDataValidationErrorStyle = "stop"
+	ExcelDataValidationErrorWarning     ExcelDataValidationErrorStyle = "warning"
+	ExcelDataValidationErrorInformation ExcelDataValidationErrorStyle = "information"
+
+	// excelDataValidationListLength 内联下拉列表的最大长度，超过时（或选项中含逗号时）自动使用辅助工作表
+	excelDataValidationListLength = 255
+	// ExcelLookupSheetName 默认辅助工作表名称（隐藏，用于存放下拉列表选项）
+	ExcelLookupSheetName = "_lookup"
+)
+
+var excelDataValidationErrorStyles = map[ExcelDataValidationErrorStyle]excelize.DataValidationErrorStyle{
+	ExcelDataValidationErrorStop:        excelize.DataValidationErrorStyleStop,
+	ExcelDataValidationErrorWarning:     excelize.DataValidationErrorStyleWarning,
+	ExcelDataValidationErrorInformation: excelize.DataValidationErrorStyleInformation,
+}
+
+// NewExcelListValidation 下拉列表
+func NewExcelListValidation(items ...string) *ExcelDataValidation {
+	return &ExcelDataValidation{validationType: ExcelDataValidationTypeList, items: items, allowBlank: true, errorStyle: ExcelDataValidationErrorStop}
+}
+
+// NewExcelIntValidation 整数范围
+func NewExcelIntValidation(min, max int) *ExcelDataValidation {
+	return &ExcelDataValidation{validationType: ExcelDataValidationTypeInt, min: min, max: max, allowBlank: true, errorStyle: ExcelDataValidationErrorStop}
+}
+
+// NewExcelDecimalValidation 小数范围
+func NewExcelDecimalValidation(min, max float64) *ExcelDataValidation {
+	return &ExcelDataValidation{validationType: ExcelDataValidationTypeDecimal, min: min, max: max, allowBlank: true, errorStyle: ExcelDataValidationErrorStop}
+}
+
+// NewExcelDateValidation 日期范围
+func NewExcelDateValidation(min, max time.Time) *ExcelDataValidation {
+	return &ExcelDataValidation{validationType: ExcelDataValidationTypeDate, min: timeToExcelSerial(min), max: timeToExcelSerial(max), allowBlank: true, errorStyle: ExcelDataValidationErrorStop}
+}
+
+// GetType 获取验证类型
+func (r *ExcelDataValidation) GetType() ExcelDataValidationType {
+	return r.validationType
+}
+
+// GetItems 获取下拉列表选项
+func (r *ExcelDataValidation) GetItems() []string {
+	return r.items
+}
+
+// GetLookupSheet 获取辅助工作表名称
+func (r *ExcelDataValidation) GetLookupSheet() string {
+	return r.lookupSheet
+}
+
+// SetLookupSheet 设置辅助工作表（下拉列表选项写入该隐藏工作表并以引用方式关联，适用于选项较多或需要复用的情况）
+func (r *ExcelDataValidation) SetLookupSheet(sheetName string) *ExcelDataValidation {
+	r.lookupSheet = sheetName
+	return r
+}
+
+// GetAllowBlank 获取是否允许为空
+func (r *ExcelDataValidation) GetAllowBlank() bool {
+	return r.allowBlank
+}
+
+// SetAllowBlank 设置是否允许为空（默认允许）
+func (r *ExcelDataValidation) SetAllowBlank(allowBlank bool) *ExcelDataValidation {
+	r.allowBlank = allowBlank
+	return r
+}
+
+// SetPrompt 设置输入提示（选中单元格时显示）
+func (r *ExcelDataValidation) SetPrompt(title, prompt string) *ExcelDataValidation {
+	r.promptTitle, r.prompt = title, prompt
+	return r
+}
+
+// SetError 设置出错提示（输入不符合要求时显示）
+func (r *ExcelDataValidation) SetError(title, message string) *ExcelDataValidation {
+	r.errorTitle, r.errorMessage = title, message
+	return r
+}
+
+// SetErrorStyle 设置出错提示样式（默认禁止输入）
+func (r *ExcelDataValidation) SetErrorStyle(errorStyle ExcelDataValidationErrorStyle) *ExcelDataValidation {
+	r.errorStyle = errorStyle
+	return r
+}
+
+// AddDataValidation 在单元格区域上设置数据验证（如：B2、B1000）
+func (r *ExcelWriter) AddDataValidation(hCell, vCell string, validation *ExcelDataValidation) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if r.stream != nil {
+		return r.fail(ErrStreamUnsupported)
+	}
+
+	dv := excelize.NewDataValidation(validation.allowBlank)
+	dv.Sqref = hCell + ":" + vCell
+
+	var err error
+	switch validation.validationType {
+	case ExcelDataValidationTypeList:
+		list := strings.Join(validation.items, ",")
+		if validation.lookupSheet != "" || len(utf16.Encode([]rune(list))) > excelDataValidationListLength || strings.Count(list, ",") >= len(validation.items) {
+			var sqref string
+			if sqref, err = r.addLookupItems(validation.lookupSheet, validation.items); err == nil {
+				dv.SetSqrefDropList(sqref)
+			}
+		} else {
+			err = dv.SetDropList(validation.items)
+		}
+	case ExcelDataValidationTypeInt:
+		err = dv.SetRange(validation.min, validation.max, excelize.DataValidationTypeWhole, excelize.DataValidationOperatorBetween)
+	case ExcelDataValidationTypeDecimal:
+		err = dv.SetRange(validation.min, validation.max, excelize.DataValidationTypeDecimal, excelize.DataValidationOperatorBetween)
+	case ExcelDataValidationTypeDate:
+		err = dv.SetRange(validation.min, validation.max, excelize.DataValidationTypeDate, excelize.DataValidationOperatorBetween)
+	}
+	if err != nil {
+		return r.fail(fmt.Errorf("设置数据验证错误（%s:%s）：%w", hCell, vCell, err))
+	}
+
+	if validation.prompt != "" || validation.promptTitle != "" {
+		dv.SetInput(validation.promptTitle, validation.prompt)
+	}
+	if validation.errorMessage != "" || validation.errorTitle != "" {
+		dv.SetError(excelDataValidationErrorStyles[validation.errorStyle], validation.errorTitle, validation.errorMessage)
+	}
+
+	if err = r.excel.AddDataValidation(r.sheetName, dv); err != nil {
+		return r.fail(fmt.Errorf("设置数据验证错误（%s:%s）：%w", hCell, vCell, err))
+	}
+
+	return r
+}
+
+// AddColumnDataValidation 在整列（startRowNumber至endRowNumber行）上设置数据验证（列号从1开始）
+func (r *ExcelWriter) AddColumnDataValidation(col int, startRowNumber, endRowNumber uint64, validation *ExcelDataValidation) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+
+	area := NewExcelRangeRef(NewExcelCellRef(col, int(startRowNumber)), NewExcelCellRef(col, int(endRowNumber)))
+	if err := area.Validate(); err != nil {
+		return r.fail(fmt.Errorf("设置数据验证错误：%w", err))
+	}
+
+	return r.AddDataValidation(area.Start.Coordinate(), area.End.Coordinate(), validation)
+}
+
+// addLookupItems 将下拉列表选项写入隐藏的辅助工作表（每个列表占一列），返回引用区域
+func (r *ExcelWriter) addLookupItems(sheetName string, items []string) (string, error) {
+	if sheetName == "" {
+		sheetName = ExcelLookupSheetName
+	}
+	if len(items) == 0 {
+		return "", errors.New("下拉列表选项不能为空")
+	}
+
+	if sheetIndex, err := r.excel.GetSheetIndex(sheetName); err != nil {
+		return "", err
+	} else if sheetIndex == -1 {
+		if _, err = r.excel.NewSheet(sheetName); err != nil {
+			return "", err
+		}
+		if err = r.excel.SetSheetVisible(sheetName, false); err != nil {
+			return "", err
+		}
+	}
+
+	cols, err := r.excel.GetCols(sheetName)
+	if err != nil {
+		return "", err
+	}
+	col := len(cols) + 1
+
+	for idx, item := range items {
+		if err = r.excel.SetCellStr(sheetName, NewExcelCellRef(col, idx+1).Coordinate(), item); err != nil {
+			return "", err
+		}
+	}
+
+	return NewExcelRangeRef(NewExcelCellRef(col, 1), NewExcelCellRef(col, len(items))).Absolute().WithSheet(sheetName).String(), nil
+}
@@ -0,0 +1,128 @@
+package excel
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// maxExcelSerial Excel支持的最大日期序列号（9999-12-31）
+const maxExcelSerial = 2958466
+
+var excelDateLayouts = []string{
+	time.RFC3339,
+	"2006-1-2 15:04:05",
+	"2006-1-2 15:04",
+	"2006-1-2",
+	"2006/1/2 15:04:05",
+	"2006/1/2 15:04",
+	"2006/1/2",
+	"2006.1.2 15:04:05",
+	"2006.1.2",
+	"2006年1月2日 15:04:05",
+	"2006年1月2日 15:04",
+	"2006年1月2日 15时4分5秒",
+	"2006年1月2日 15时4分",
+	"2006年1月2日15时4分5秒",
+	"2006年1月2日",
+	"2006年1月",
+	"20060102150405",
+	"20060102",
+	"15:04:05",
+	"15:04",
+	"15时4分5秒",
+}
+
+// ExcelSerialToTime Excel日期序列号转时间（返回本地时区的同一时刻读数）
+func ExcelSerialToTime(serial float64) (time.Time, error) {
+	t, err := excelize.ExcelDateToTime(serial, false)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.Local), nil
+}
+
+// ParseExcelDate 单元格文本转时间（支持常见格式，如：2024-01-02、2024/1/2、2024年1月2日；
+// 纯数字如2024不视为Excel序列号，数字单元格的序列号使用ParseExcelSerial）
+func ParseExcelDate(content string) (time.Time, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return time.Time{}, fmt.Errorf("无法转换为时间：%s", content)
+	}
+
+	for _, layout := range excelDateLayouts {
+		if t, err := time.ParseInLocation(layout, content, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("无法转换为时间：%s", content)
+}
+
+// ParseExcelSerial Excel日期序列号文本转时间（仅用于数字单元格的原始值）
+func ParseExcelSerial(content string) (time.Time, error) {
+	serial, err := strconv.ParseFloat(strings.TrimSpace(content), 64)
+	if err != nil || serial < 0 || serial >= maxExcelSerial {
+		return time.Time{}, fmt.Errorf("无法转换为时间：%s", content)
+	}
+	return ExcelSerialToTime(serial)
+}
+
+// ParseExcelDuration 单元格内容转时长（支持Excel天数小数、[h]:mm:ss、h:mm及Go时长格式如：1h30m）
+func ParseExcelDuration(content string) (time.Duration, error) {
+	content = strings.TrimSpace(content)
+
+	if days, err := strconv.ParseFloat(content, 64); err == nil {
+		return time.Duration(math.Round(days * 24 * float64(time.Hour))), nil
+	}
+
+	if parts := strings.Split(content, ":"); len(parts) == 2 || len(parts) == 3 {
+		var (
+			duration time.Duration
+			units    = []time.Duration{time.Hour, time.Minute, time.Second}
+			negative = strings.HasPrefix(parts[0], "-")
+		)
+		parts[0] = strings.TrimPrefix(parts[0], "-")
+		for idx, part := range parts {
+			value, err := strconv.ParseFloat(part, 64)
+			if err != nil || value < 0 {
+				return 0, fmt.Errorf("无法转换为时长：%s", content)
+			}
+			duration += time.Duration(value * float64(units[idx]))
+		}
+		if negative {
+			duration = -duration
+		}
+		return duration, nil
+	}
+
+	if duration, err := time.ParseDuration(content); err == nil {
+		return duration, nil
+	}
+
+	return 0, fmt.Errorf("无法转换为时长：%s", content)
+}
+
+// durationToExcelDays 时长转Excel天数
+func durationToExcelDays(duration time.Duration) float64 {
+	return duration.Hours() / 24
+}
+
+// excelTimeValue 写入单元格的时间值（年份为0的时间只有时分秒，如time.Parse("15:04", ...)的结果，转为一天中的小数）
+func excelTimeValue(t time.Time) any {
+	if t.Year() != 0 {
+		return t
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return t.Sub(midnight).Seconds() / 86400
+}
+
+// timeToExcelSerial 时间转Excel日期序列号（按本地时区的读数计算）
+func timeToExcelSerial(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	return wall.Sub(time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)).Hours() / 24
+}
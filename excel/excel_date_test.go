@@ -0,0 +1,73 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestParseExcelDate(t *testing.T) {
+	tests := []struct {
+		content string
+		want    time.Time
+		wantErr bool
+	}{
+		{content: "2024-01-02", want: time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)},
+		{content: "20240102", want: time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)},
+		{content: "2024年1月2日", want: time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)},
+		{content: "2024", wantErr: true},
+		{content: "45293", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseExcelDate(tt.content)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseExcelDate(%q): got %v, want error", tt.content, got)
+			}
+			continue
+		}
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseExcelDate(%q): got %v, %v, want %v", tt.content, got, err, tt.want)
+		}
+	}
+
+	if got, err := ParseExcelSerial("45293"); err != nil || !got.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("ParseExcelSerial: got %v, %v", got, err)
+	}
+}
+
+func TestTimeOfDayCell(t *testing.T) {
+	clock, err := time.Parse("15:04", "13:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, stream := range []bool{false, true} {
+		filename := filepath.Join(t.TempDir(), "time.xlsx")
+		writer := NewExcelWriter(filename).SetCollectErr(true).ActiveSheetByIndex(0)
+		if stream {
+			writer.StartStream()
+		}
+		writer.AddRow(NewExcelRow().SetRowNumber(1).SetCells([]*ExcelCell{NewExcelCellTime(clock)}))
+		if err := writer.Save(); err != nil {
+			t.Fatalf("Save(stream=%v): %v", stream, err)
+		}
+
+		f, err := excelize.OpenFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, _ := f.GetCellValue("Sheet1", "A1", excelize.Options{RawCellValue: true})
+		text, _ := f.GetCellValue("Sheet1", "A1")
+		_ = f.Close()
+		if raw != "0.5625" {
+			t.Errorf("stream=%v raw: got %q, want 0.5625", stream, raw)
+		}
+		if text != "13:30:00" {
+			t.Errorf("stream=%v text: got %q, want 13:30:00", stream, text)
+		}
+	}
+}
@@ -0,0 +1,434 @@
+package excel
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// ExcelDiffType 行变更类型
+	ExcelDiffType string
+
+	// ExcelDiff 工作表比较器（根据主键列匹配新旧两个版本的行）
+	ExcelDiff struct {
+		keyTitles    []string
+		ignoreTitles map[string]bool
+		trimSpace    bool
+	}
+
+	// ExcelDiffCell 变更的单元格
+	ExcelDiffCell struct {
+		title    string
+		oldValue string
+		newValue string
+	}
+
+	// ExcelDiffRow 变更的行
+	ExcelDiffRow struct {
+		diffType     ExcelDiffType
+		key          []string
+		oldRowNumber uint64
+		newRowNumber uint64
+		oldValues    map[string]string
+		newValues    map[string]string
+		cells        []*ExcelDiffCell
+	}
+
+	// ExcelDiffReport 比较结果
+	ExcelDiffReport struct {
+		sheetName string
+		keyTitles []string
+		titles    []string
+		rows      []*ExcelDiffRow
+	}
+
+	// excelDiffSide 按主键索引的一侧数据
+	excelDiffSide struct {
+		titles []string
+		keys   []string
+		rows   map[string]*ExcelDiffRow
+	}
+)
+
+const (
+	ExcelDiffAdded    ExcelDiffType = "added"
+	ExcelDiffRemoved  ExcelDiffType = "removed"
+	ExcelDiffModified ExcelDiffType = "modified"
+
+	// ExcelDiffTypeTitle 导出比较结果时变更类型列的表头
+	ExcelDiffTypeTitle = "变更类型"
+)
+
+var (
+	// excelDiffLabels 变更类型名称
+	excelDiffLabels = map[ExcelDiffType]string{ExcelDiffAdded: "新增", ExcelDiffRemoved: "删除", ExcelDiffModified: "修改"}
+	// excelDiffColors 导出时的填充颜色
+	excelDiffColors = map[ExcelDiffType]string{ExcelDiffAdded: "#C6EFCE", ExcelDiffRemoved: "#FFC7CE", ExcelDiffModified: "#FFEB9C"}
+)
+
+// NewExcelDiff 构造函数（keyTitles为主键列，默认比较时忽略首尾空白）
+func NewExcelDiff(keyTitles ...string) *ExcelDiff {
+	return &ExcelDiff{keyTitles: keyTitles, ignoreTitles: make(map[string]bool), trimSpace: true}
+}
+
+// SetIgnoreTitles 设置不参与比较的列
+func (r *ExcelDiff) SetIgnoreTitles(titles ...string) *ExcelDiff {
+	for _, title := range titles {
+		r.ignoreTitles[title] = true
+	}
+	return r
+}
+
+// SetTrimSpace 设置比较时是否忽略首尾空白
+func (r *ExcelDiff) SetTrimSpace(trimSpace bool) *ExcelDiff {
+	r.trimSpace = trimSpace
+	return r
+}
+
+// Compare 比较两个已读取的工作表（须已调用ReadTitle、Read），主键重复时按出现顺序依次匹配
+func (r *ExcelDiff) Compare(oldReader, newReader *ExcelReader) (*ExcelDiffReport, error) {
+	if len(r.keyTitles) == 0 {
+		return nil, errors.New("未设置主键列")
+	}
+
+	oldSide, err := r.index(oldReader)
+	if err != nil {
+		return nil, fmt.Errorf("旧版本：%w", err)
+	}
+	newSide, err := r.index(newReader)
+	if err != nil {
+		return nil, fmt.Errorf("新版本：%w", err)
+	}
+
+	report := &ExcelDiffReport{keyTitles: r.keyTitles, titles: newSide.titles}
+	for _, reader := range []*ExcelReader{newReader, oldReader} {
+		if reader != nil && report.sheetName == "" {
+			report.sheetName = reader.GetSheetName()
+		}
+	}
+	for _, title := range oldSide.titles {
+		if !containsString(report.titles, title) {
+			report.titles = append(report.titles, title)
+		}
+	}
+
+	for _, key := range newSide.keys {
+		newRow := newSide.rows[key]
+		oldRow, exist := oldSide.rows[key]
+		if !exist {
+			newRow.diffType = ExcelDiffAdded
+			report.rows = append(report.rows, newRow)
+			continue
+		}
+
+		row := &ExcelDiffRow{
+			diffType:     ExcelDiffModified,
+			key:          newRow.key,
+			oldRowNumber: oldRow.oldRowNumber,
+			newRowNumber: newRow.newRowNumber,
+			oldValues:    oldRow.oldValues,
+			newValues:    newRow.newValues,
+		}
+		for _, title := range report.titles {
+			if r.ignoreTitles[title] {
+				continue
+			}
+			oldValue, newValue := oldRow.oldValues[title], newRow.newValues[title]
+			if r.normalize(oldValue) != r.normalize(newValue) {
+				row.cells = append(row.cells, &ExcelDiffCell{title: title, oldValue: oldValue, newValue: newValue})
+			}
+		}
+		if len(row.cells) > 0 {
+			report.rows = append(report.rows, row)
+		}
+	}
+
+	for _, key := range oldSide.keys {
+		if _, exist := newSide.rows[key]; !exist {
+			oldRow := oldSide.rows[key]
+			oldRow.diffType = ExcelDiffRemoved
+			report.rows = append(report.rows, oldRow)
+		}
+	}
+
+	return report, nil
+}
+
+// CompareWorkbooks 比较两个工作簿中的同名工作表（自动识别表头行），只存在于一侧的工作表全部为新增或删除
+func (r *ExcelDiff) CompareWorkbooks(oldReader, newReader *ExcelReader) ([]*ExcelDiffReport, error) {
+	var (
+		oldSheets  = make(map[string]*ExcelReader)
+		sheetNames []string
+		reports    []*ExcelDiffReport
+	)
+
+	for _, reader := range oldReader.ReadAll(nil) {
+		oldSheets[reader.GetSheetName()] = reader
+		sheetNames = append(sheetNames, reader.GetSheetName())
+	}
+	if err := oldReader.Err(); err != nil {
+		return nil, err
+	}
+
+	newSheets := newReader.ReadAll(nil)
+	if err := newReader.Err(); err != nil {
+		return nil, err
+	}
+	for _, reader := range newSheets {
+		report, err := r.Compare(oldSheets[reader.GetSheetName()], reader)
+		if err != nil {
+			return nil, fmt.Errorf("工作表%s：%w", reader.GetSheetName(), err)
+		}
+		reports = append(reports, report)
+		delete(oldSheets, reader.GetSheetName())
+	}
+	for _, sheetName := range sheetNames {
+		if reader, exist := oldSheets[sheetName]; exist {
+			report, err := r.Compare(reader, nil)
+			if err != nil {
+				return nil, fmt.Errorf("工作表%s：%w", sheetName, err)
+			}
+			reports = append(reports, report)
+		}
+	}
+
+	return reports, nil
+}
+
+// index 按主键索引工作表数据
+func (r *ExcelDiff) index(reader *ExcelReader) (*excelDiffSide, error) {
+	side := &excelDiffSide{rows: make(map[string]*ExcelDiffRow)}
+	if reader == nil {
+		return side, nil
+	}
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(reader.GetTitle()) == 0 && len(reader.ToList()) == 0 {
+		// 空工作表
+		return side, nil
+	}
+
+	side.titles = UniqueTitles(reader.GetTitle())
+	var missing []string
+	for _, title := range r.keyTitles {
+		if !containsString(side.titles, title) {
+			missing = append(missing, title)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w：%s", ErrTitleRequired, strings.Join(missing, "、"))
+	}
+
+	data := reader.ToMap("")
+	for _, rowNumber := range reader.GetRowNumbers() {
+		values := data[rowNumber]
+		key := make([]string, len(r.keyTitles))
+		for idx, title := range r.keyTitles {
+			key[idx] = r.normalize(values[title])
+		}
+
+		// 主键重复时追加出现次数，按顺序与另一侧匹配
+		indexKey := strings.Join(key, "\x1f")
+		for occurrence := 2; side.rows[indexKey] != nil; occurrence++ {
+			indexKey = strings.Join(key, "\x1f") + "\x1f#" + strconv.Itoa(occurrence)
+		}
+
+		excelRowNumber := reader.GetExcelRowNumber(rowNumber)
+		side.keys = append(side.keys, indexKey)
+		side.rows[indexKey] = &ExcelDiffRow{key: key, oldRowNumber: excelRowNumber, newRowNumber: excelRowNumber, oldValues: values, newValues: values}
+	}
+
+	return side, nil
+}
+
+// normalize 比较前处理
+func (r *ExcelDiff) normalize(value string) string {
+	if r.trimSpace {
+		return strings.TrimSpace(value)
+	}
+	return value
+}
+
+// GetType 获取变更类型
+func (r *ExcelDiffRow) GetType() ExcelDiffType {
+	return r.diffType
+}
+
+// GetKey 获取主键值
+func (r *ExcelDiffRow) GetKey() []string {
+	return r.key
+}
+
+// GetOldRowNumber 获取旧版本中的行号（新增的行为0）
+func (r *ExcelDiffRow) GetOldRowNumber() uint64 {
+	if r.diffType == ExcelDiffAdded {
+		return 0
+	}
+	return r.oldRowNumber
+}
+
+// GetNewRowNumber 获取新版本中的行号（删除的行为0）
+func (r *ExcelDiffRow) GetNewRowNumber() uint64 {
+	if r.diffType == ExcelDiffRemoved {
+		return 0
+	}
+	return r.newRowNumber
+}
+
+// GetOldValues 获取旧版本的行数据（新增的行为nil）
+func (r *ExcelDiffRow) GetOldValues() map[string]string {
+	if r.diffType == ExcelDiffAdded {
+		return nil
+	}
+	return r.oldValues
+}
+
+// GetNewValues 获取新版本的行数据（删除的行为nil）
+func (r *ExcelDiffRow) GetNewValues() map[string]string {
+	if r.diffType == ExcelDiffRemoved {
+		return nil
+	}
+	return r.newValues
+}
+
+// GetCells 获取变更的单元格（仅修改的行）
+func (r *ExcelDiffRow) GetCells() []*ExcelDiffCell {
+	return r.cells
+}
+
+// GetTitle 获取表头
+func (r *ExcelDiffCell) GetTitle() string {
+	return r.title
+}
+
+// GetOldValue 获取旧值
+func (r *ExcelDiffCell) GetOldValue() string {
+	return r.oldValue
+}
+
+// GetNewValue 获取新值
+func (r *ExcelDiffCell) GetNewValue() string {
+	return r.newValue
+}
+
+// GetSheetName 获取工作表名称
+func (r *ExcelDiffReport) GetSheetName() string {
+	return r.sheetName
+}
+
+// GetTitles 获取表头（新版本的表头在前，旧版本独有的表头在后）
+func (r *ExcelDiffReport) GetTitles() []string {
+	return r.titles
+}
+
+// GetRows 获取全部变更的行（新增、修改按新版本顺序，删除的行在最后）
+func (r *ExcelDiffReport) GetRows() []*ExcelDiffRow {
+	return r.rows
+}
+
+// GetAdded 获取新增的行
+func (r *ExcelDiffReport) GetAdded() []*ExcelDiffRow {
+	return r.filter(ExcelDiffAdded)
+}
+
+// GetRemoved 获取删除的行
+func (r *ExcelDiffReport) GetRemoved() []*ExcelDiffRow {
+	return r.filter(ExcelDiffRemoved)
+}
+
+// GetModified 获取修改的行
+func (r *ExcelDiffReport) GetModified() []*ExcelDiffRow {
+	return r.filter(ExcelDiffModified)
+}
+
+// HasChanges 是否有变更
+func (r *ExcelDiffReport) HasChanges() bool {
+	return len(r.rows) > 0
+}
+
+// GetSummary 获取变更摘要（如：新增2行，删除1行，修改3行）
+func (r *ExcelDiffReport) GetSummary() string {
+	return fmt.Sprintf("新增%d行，删除%d行，修改%d行", len(r.GetAdded()), len(r.GetRemoved()), len(r.GetModified()))
+}
+
+// filter 按变更类型筛选
+func (r *ExcelDiffReport) filter(diffType ExcelDiffType) []*ExcelDiffRow {
+	var rows []*ExcelDiffRow
+	for _, row := range r.rows {
+		if row.diffType == diffType {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// Export 导出比较结果（新增的行标绿、删除的行标红、修改的单元格标黄并以批注说明原值）
+func (r *ExcelDiffReport) Export(filename string, a ...any) *ExcelWriter {
+	return ExportExcelDiff(fmt.Sprintf(filename, a...), r)
+}
+
+// ExportExcelDiff 导出多个比较结果（每个工作表的比较结果写入同名工作表）
+func ExportExcelDiff(filename string, reports ...*ExcelDiffReport) *ExcelWriter {
+	writer := NewExcelWriter("%s", filename)
+
+	for idx, report := range reports {
+		sheetName := report.sheetName
+		if sheetName == "" {
+			sheetName = fmt.Sprintf("Sheet%d", idx+1)
+		}
+		if idx == 0 {
+			writer.excel.SetSheetName(writer.sheetName, sheetName)
+			writer.ActiveSheetByName(sheetName)
+		} else {
+			writer.CreateSheet(sheetName)
+		}
+		report.writeTo(writer)
+	}
+
+	return writer
+}
+
+// writeTo 将比较结果写入当前工作表
+func (r *ExcelDiffReport) writeTo(writer *ExcelWriter) {
+	writer.SetTitleRow(append([]string{ExcelDiffTypeTitle}, r.titles...), 1)
+
+	for idx, row := range r.rows {
+		values := row.newValues
+		if row.diffType == ExcelDiffRemoved {
+			values = row.oldValues
+		}
+		changed := make(map[string]*ExcelDiffCell, len(row.cells))
+		for _, cell := range row.cells {
+			changed[cell.title] = cell
+		}
+
+		cells := make([]*ExcelCell, 0, len(r.titles)+1)
+		cells = append(cells, NewExcelCellAny(excelDiffLabels[row.diffType]).SetFillColor(excelDiffColors[row.diffType], true))
+		for _, title := range r.titles {
+			cell := NewExcelCellAny(values[title])
+			if diffCell, exist := changed[title]; exist {
+				cell.SetFillColor(excelDiffColors[ExcelDiffModified], true).SetComment("比较", "原值："+diffCell.oldValue, true)
+			}
+			cell.SetFillColor(excelDiffColors[row.diffType], row.diffType != ExcelDiffModified)
+			cells = append(cells, cell)
+		}
+
+		writer.AddRow(NewExcelRow().SetCollectErr(writer.collectErr).SetRowNumber(uint64(idx) + 2).SetCells(cells))
+	}
+
+	writer.FreezePanes(1, 0)
+}
+
+// containsString 切片中是否包含字符串
+func containsString(items []string, item string) bool {
+	for _, v := range items {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
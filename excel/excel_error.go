@@ -0,0 +1,82 @@
+package excel
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var (
+	ErrFilenameEmpty       = errors.New("文件名不能为空")
+	ErrFileNotOpened       = errors.New("未打开文件")
+	ErrSheetNameNotSet     = errors.New("未设置工作表名称")
+	ErrSheetNameEmpty      = errors.New("工作表名称不能为空")
+	ErrSheetIndexInvalid   = errors.New("工作表索引不能小于0")
+	ErrSheetNotFound       = errors.New("工作表不存在")
+	ErrTitleRowMissing     = errors.New("表头行不存在")
+	ErrTitleNotSet         = errors.New("未设置表头")
+	ErrTitleEmpty          = errors.New("表头不能为空")
+	ErrTitleRequired       = errors.New("缺少必需表头")
+	ErrRowNumberInvalid    = errors.New("行标必须大于0")
+	ErrCellContentInvalid  = errors.New("单元格内容与类型不匹配")
+	ErrStreamRowOrder      = errors.New("流式写入行号必须递增")
+	ErrStreamUnsupported   = errors.New("流式写入模式不支持该操作，请在Flush之后调用")
+	ErrStreamSheetNotEmpty = errors.New("流式写入会覆盖工作表，只能用于没有数据的工作表")
+	ErrReferenceInvalid    = errors.New("单元格引用格式错误")
+	ErrReferenceOutOfRange = errors.New("单元格引用超出范围")
+)
+
+// CellError 单元格错误（携带坐标）
+type CellError struct {
+	RowNumber    uint64
+	ColumnNumber int
+	Coordinate   string
+	Title        string
+	Content      string
+	Err          error
+}
+
+// NewCellError 构造函数
+func NewCellError(rowNumber uint64, columnNumber int, title, content string, err error) *CellError {
+	coordinate, _ := excelize.CoordinatesToCellName(columnNumber, int(rowNumber))
+	return &CellError{
+		RowNumber:    rowNumber,
+		ColumnNumber: columnNumber,
+		Coordinate:   coordinate,
+		Title:        title,
+		Content:      content,
+		Err:          err,
+	}
+}
+
+// NewCellErrorByCoordinate 构造函数（根据单元格坐标）
+func NewCellErrorByCoordinate(coordinate string, content any, err error) *CellError {
+	columnNumber, rowNumber, _ := excelize.CellNameToCoordinates(coordinate)
+	return &CellError{
+		RowNumber:    uint64(rowNumber),
+		ColumnNumber: columnNumber,
+		Coordinate:   coordinate,
+		Content:      fmt.Sprintf("%v", content),
+		Err:          err,
+	}
+}
+
+// GetColumnText 获取列文字
+func (r *CellError) GetColumnText() string {
+	columnText, _ := ColumnNumberToText(r.ColumnNumber)
+	return columnText
+}
+
+// Error 实现error接口
+func (r *CellError) Error() string {
+	if r.Title != "" {
+		return fmt.Sprintf("第%d行 列%s（%s）：%s", r.RowNumber, r.GetColumnText(), r.Title, r.Err.Error())
+	}
+	return fmt.Sprintf("第%d行 列%s：%s", r.RowNumber, r.GetColumnText(), r.Err.Error())
+}
+
+// Unwrap 获取原始错误
+func (r *CellError) Unwrap() error {
+	return r.Err
+}
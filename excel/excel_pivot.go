@@ -0,0 +1,324 @@
+package excel
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type (
+	// ExcelPivot 交叉表（行分组×列分组，交叉处为汇总值，最后一列为行合计、最后一行为列合计；求和、计数、最大值、最小值的合计使用公式，便于在Excel中核对）
+	ExcelPivot struct {
+		titles       []string
+		records      []*excelAggregateRecord
+		rowTitles    []string
+		columnTitle  string
+		measure      *ExcelAggregateMeasure
+		titleRow     uint64
+		rowTotal     bool
+		columnTotal  bool
+		totalLabel   string
+		blankLabel   string
+		totalStyler  ExcelCellStyler
+		numberFormat string
+		err          error
+	}
+
+	// excelPivotGroup 交叉表的行或列分组
+	excelPivotGroup struct {
+		values  []any
+		records []*excelAggregateRecord
+	}
+)
+
+// NewExcelPivotByReader 构造函数（使用已读取的数据，须已调用ReadTitle、Read，重复的表头依次追加序号）
+func NewExcelPivotByReader(reader *ExcelReader) *ExcelPivot {
+	r := newExcelPivot()
+	r.titles, r.records, r.err = aggregateRecordsByReader(reader)
+	return r
+}
+
+// NewExcelPivotByStructs 构造函数（使用结构体切片，列名由`excel:"标题"`标签决定，同WriteStructs）
+func NewExcelPivotByStructs(data any) *ExcelPivot {
+	r := newExcelPivot()
+	r.titles, r.records, r.err = aggregateRecordsByStructs(data)
+	return r
+}
+
+// newExcelPivot 默认配置（第一行是表头，输出行合计及列合计）
+func newExcelPivot() *ExcelPivot {
+	return &ExcelPivot{
+		titleRow:    1,
+		rowTotal:    true,
+		columnTotal: true,
+		totalLabel:  "合计",
+		blankLabel:  "（空白）",
+		totalStyler: func(cell *ExcelCell) *ExcelCell { return cell.SetFontBold(true, true).SetFillColor("#D9D9D9", true) },
+	}
+}
+
+// Err 获取错误（构造或配置时的错误，写入时同样会返回）
+func (r *ExcelPivot) Err() error {
+	return r.err
+}
+
+// SetRows 设置行分组列（可多列，按首次出现的顺序排列）
+func (r *ExcelPivot) SetRows(titles ...string) *ExcelPivot {
+	r.rowTitles = titles
+	return r
+}
+
+// SetColumn 设置列分组列（每个不同的值输出为一列，按首次出现的顺序排列）
+func (r *ExcelPivot) SetColumn(title string) *ExcelPivot {
+	r.columnTitle = title
+	return r
+}
+
+// SetMeasure 设置汇总列及汇总方式
+func (r *ExcelPivot) SetMeasure(title string, fn ExcelAggregateFunc) *ExcelPivot {
+	if _, exist := excelAggregateLabels[fn]; !exist && r.err == nil {
+		r.err = fmt.Errorf("不支持的汇总方式：%s", fn)
+	}
+	r.measure = &ExcelAggregateMeasure{title: title, fn: fn, outputTitle: title}
+	return r
+}
+
+// GetMeasure 获取汇总列
+func (r *ExcelPivot) GetMeasure() *ExcelAggregateMeasure {
+	return r.measure
+}
+
+// GetTitleRow 获取表头行
+func (r *ExcelPivot) GetTitleRow() uint64 {
+	return r.titleRow
+}
+
+// SetTitleRow 设置表头行
+func (r *ExcelPivot) SetTitleRow(titleRow uint64) *ExcelPivot {
+	r.titleRow = titleRow
+	return r
+}
+
+// SetRowTotal 设置是否输出行合计列（默认输出）
+func (r *ExcelPivot) SetRowTotal(rowTotal bool) *ExcelPivot {
+	r.rowTotal = rowTotal
+	return r
+}
+
+// SetColumnTotal 设置是否输出列合计行（默认输出）
+func (r *ExcelPivot) SetColumnTotal(columnTotal bool) *ExcelPivot {
+	r.columnTotal = columnTotal
+	return r
+}
+
+// SetTotalLabel 设置合计名称（默认为：合计）
+func (r *ExcelPivot) SetTotalLabel(totalLabel string) *ExcelPivot {
+	r.totalLabel = totalLabel
+	return r
+}
+
+// SetBlankLabel 设置列分组值为空时的列名（默认为：（空白））
+func (r *ExcelPivot) SetBlankLabel(blankLabel string) *ExcelPivot {
+	r.blankLabel = blankLabel
+	return r
+}
+
+// SetTotalStyler 设置合计行、合计列样式（默认加粗、灰色填充，nil为不设置样式）
+func (r *ExcelPivot) SetTotalStyler(styler ExcelCellStyler) *ExcelPivot {
+	r.totalStyler = styler
+	return r
+}
+
+// SetNumberFormat 设置汇总值及合计的数字格式
+func (r *ExcelPivot) SetNumberFormat(numberFormat string) *ExcelPivot {
+	r.numberFormat = numberFormat
+	return r
+}
+
+// GetTitle 获取源数据列名
+func (r *ExcelPivot) GetTitle() []string {
+	return r.titles
+}
+
+// check 检查配置
+func (r *ExcelPivot) check() error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.rowTitles) == 0 {
+		return errors.New("未设置行分组列")
+	}
+	if r.columnTitle == "" {
+		return errors.New("未设置列分组列")
+	}
+	if r.measure == nil {
+		return errors.New("未设置汇总列")
+	}
+
+	var missing []string
+	for _, title := range append(append([]string{}, r.rowTitles...), r.columnTitle, r.measure.title) {
+		if !containsString(r.titles, title) && !containsString(missing, title) {
+			missing = append(missing, title)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w：%s", ErrTitleRequired, strings.Join(missing, "、"))
+	}
+
+	return nil
+}
+
+// columnLabel 列分组的列名
+func (r *ExcelPivot) columnLabel(value any) string {
+	if label := fmt.Sprint(value); label != "" {
+		return label
+	}
+	return r.blankLabel
+}
+
+// pivotGroups 按titles分组（按首次出现的顺序），同时返回每行源数据所属分组的序号
+func pivotGroups(records []*excelAggregateRecord, titles []string) ([]*excelPivotGroup, []int) {
+	var (
+		groups  []*excelPivotGroup
+		indexes = make([]int, len(records))
+		index   = make(map[string]int)
+	)
+
+	for idx, record := range records {
+		var (
+			values = make([]any, len(titles))
+			keys   = make([]string, len(titles))
+		)
+		for titleIdx, title := range titles {
+			values[titleIdx] = record.values[title]
+			keys[titleIdx] = fmt.Sprint(values[titleIdx])
+		}
+
+		key := strings.Join(keys, "\x00")
+		groupIdx, exist := index[key]
+		if !exist {
+			groupIdx = len(groups)
+			index[key] = groupIdx
+			groups = append(groups, &excelPivotGroup{values: values})
+		}
+		groups[groupIdx].records = append(groups[groupIdx].records, record)
+		indexes[idx] = groupIdx
+	}
+
+	return groups, indexes
+}
+
+// WritePivot 写入交叉表：行分组列在前，每个列分组一列，交叉处为汇总值（没有数据时为空）；
+// 求和、计数、最大值、最小值的合计使用SUM、MAX、MIN公式引用交叉单元格，平均值的合计写入根据源数据计算的结果
+func (r *ExcelWriter) WritePivot(pivot *ExcelPivot) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if err := pivot.check(); err != nil {
+		return r.fail(err)
+	}
+
+	var (
+		rowGroups, rowIndexes = pivotGroups(pivot.records, pivot.rowTitles)
+		colGroups, colIndexes = pivotGroups(pivot.records, []string{pivot.columnTitle})
+		intersections         = make([][][]*excelAggregateRecord, len(rowGroups))
+		rowCols               = len(pivot.rowTitles)
+		firstRowNumber        = int(pivot.GetTitleRow()) + 1
+		lastRowNumber         = firstRowNumber + len(rowGroups) - 1
+		titles                = append([]string{}, pivot.rowTitles...)
+	)
+	for idx := range intersections {
+		intersections[idx] = make([][]*excelAggregateRecord, len(colGroups))
+	}
+	for idx, record := range pivot.records {
+		intersections[rowIndexes[idx]][colIndexes[idx]] = append(intersections[rowIndexes[idx]][colIndexes[idx]], record)
+	}
+
+	for _, group := range colGroups {
+		titles = append(titles, pivot.columnLabel(group.values[0]))
+	}
+	if pivot.rowTotal {
+		titles = append(titles, pivot.totalLabel)
+	}
+	r.SetTitleRow(titles, pivot.GetTitleRow())
+	if len(pivot.records) == 0 {
+		return r
+	}
+
+	// totalCell 合计单元格（ref为合计的单元格区域，records为对应的源数据）
+	totalCell := func(ref string, records []*excelAggregateRecord) (*ExcelCell, error) {
+		if name := excelAggregateFormulas[pivot.measure.fn][1]; name != "" {
+			return NewExcelCellFormula(name + "(" + ref + ")"), nil
+		}
+		value, err := aggregateMeasureValue(pivot.titles, pivot.measure, records)
+		if err != nil {
+			return nil, err
+		}
+		return newExcelCellByValue(value), nil
+	}
+	addRow := func(rowNumber int, cells []*ExcelCell, totalFrom int) {
+		for idx, cell := range cells {
+			if idx >= rowCols && pivot.numberFormat != "" {
+				cell.SetNumberFormat(pivot.numberFormat)
+			}
+			if idx >= totalFrom && pivot.totalStyler != nil {
+				cells[idx] = pivot.totalStyler(cell)
+			}
+		}
+		r.AddRow(NewExcelRow().SetCollectErr(r.collectErr).SetRowNumber(uint64(rowNumber)).SetCells(cells))
+	}
+
+	for rowIdx, rowGroup := range rowGroups {
+		rowNumber := firstRowNumber + rowIdx
+		cells := make([]*ExcelCell, 0, len(titles))
+		for _, value := range rowGroup.values {
+			cells = append(cells, newExcelCellByValue(value))
+		}
+		for colIdx := range colGroups {
+			if len(intersections[rowIdx][colIdx]) == 0 {
+				cells = append(cells, NewExcelCellAny(""))
+				continue
+			}
+			value, err := aggregateMeasureValue(pivot.titles, pivot.measure, intersections[rowIdx][colIdx])
+			if err != nil {
+				return r.fail(err)
+			}
+			cells = append(cells, newExcelCellByValue(value))
+		}
+		if pivot.rowTotal {
+			ref := NewExcelRangeRef(NewExcelCellRef(rowCols+1, rowNumber), NewExcelCellRef(rowCols+len(colGroups), rowNumber)).String()
+			cell, err := totalCell(ref, rowGroup.records)
+			if err != nil {
+				return r.fail(err)
+			}
+			cells = append(cells, cell)
+		}
+		addRow(rowNumber, cells, rowCols+len(colGroups))
+	}
+
+	if pivot.columnTotal {
+		cells := make([]*ExcelCell, 0, len(titles))
+		for idx := 0; idx < rowCols; idx++ {
+			if idx == 0 {
+				cells = append(cells, NewExcelCellAny(pivot.totalLabel))
+			} else {
+				cells = append(cells, NewExcelCellAny(""))
+			}
+		}
+		for colIdx := 0; colIdx < len(titles)-rowCols; colIdx++ {
+			records := pivot.records
+			if colIdx < len(colGroups) {
+				records = colGroups[colIdx].records
+			}
+			ref := NewExcelRangeRef(NewExcelCellRef(rowCols+colIdx+1, firstRowNumber), NewExcelCellRef(rowCols+colIdx+1, lastRowNumber)).String()
+			cell, err := totalCell(ref, records)
+			if err != nil {
+				return r.fail(err)
+			}
+			cells = append(cells, cell)
+		}
+		addRow(lastRowNumber+1, cells, 0)
+	}
+
+	return r
+}
@@ -1,8 +1,10 @@
 package excel
 
 import (
-	"errors"
 	"fmt"
+	"io"
+	"sort"
+	"strings"
 
 	"github.com/go-gota/gota/dataframe"
 	"github.com/go-gota/gota/series"
@@ -11,14 +13,27 @@ import (
 
 // ExcelReader Excel读取器
 type ExcelReader struct {
-	data        map[uint64][]string
-	excel       *excelize.File
-	sheetName   string
-	originalRow int
-	finishedRow int
-	titleRow    int
-	titles      []string
-	content     [][]string
+	data           map[uint64][]string
+	excel          *excelize.File
+	sheetName      string
+	originalRow    int
+	finishedRow    int
+	titleRow       int
+	titles         []string
+	rawTitles      []string
+	header         *ExcelHeader
+	report         *ExcelHeaderReport
+	content        [][]string
+	rules          []*ExcelRule
+	typedData      map[uint64][]*ExcelCellValue
+	mergeOrigins   map[ExcelCellRef]string
+	calcFormula    bool
+	fillMerged     bool
+	titleSeparator string
+	csvDelimiter   rune
+	csvEncoding    string
+	collectErr     bool
+	err            error
 }
 
 // NewExcelReader 构造函数
@@ -26,13 +41,45 @@ func NewExcelReader() *ExcelReader {
 	return &ExcelReader{}
 }
 
-// AutoRead 自动读取（默认第一行是表头，从第二行开始，默认Sheet名称为：Sheet1）
+// SetCollectErr 设置错误收集模式（开启后出错不再panic，通过Err获取第一个错误，后续操作不再执行）
+func (r *ExcelReader) SetCollectErr(collectErr bool) *ExcelReader {
+	r.collectErr = collectErr
+	return r
+}
+
+// Err 获取错误
+func (r *ExcelReader) Err() error {
+	return r.err
+}
+
+// fail 记录错误（未开启错误收集模式时panic）
+func (r *ExcelReader) fail(err error) *ExcelReader {
+	if r.err == nil {
+		r.err = err
+	}
+	if !r.collectErr {
+		panic(err)
+	}
+	return r
+}
+
+// failed 错误收集模式下是否已出错
+func (r *ExcelReader) failed() bool {
+	return r.collectErr && r.err != nil
+}
+
+// AutoRead 自动读取（默认第一行是表头，从第二行开始，默认Sheet名称为：Sheet1，不存在时读取第一个工作表）
 func (r *ExcelReader) AutoRead(filename string, values ...any) *ExcelReader {
+	r.OpenFile(filename, values...)
+	if sheetIndex, _ := r.getSheetIndex("Sheet1"); r.excel != nil && sheetIndex == -1 {
+		r.SetSheetIndex(0)
+	} else {
+		r.SetSheetName("Sheet1")
+	}
+
 	return r.
-		OpenFile(filename, values...).
 		SetOriginalRow(2).
 		SetTitleRow(1).
-		SetSheetName("Sheet1").
 		ReadTitle().
 		Read()
 }
@@ -53,25 +100,27 @@ func (r *ExcelReader) ToList() map[uint64][]string {
 	return r.data
 }
 
-// ToMap 获取数据（map类型）
+// ToMap 获取数据（map类型，重复的表头依次追加序号，如：备注、备注_2）
 func (r *ExcelReader) ToMap(defaultValue string) map[uint64]map[string]string {
 	if len(r.GetTitle()) == 0 {
-		panic(errors.New("未设置表头"))
+		r.fail(ErrTitleNotSet)
+		return nil
 	}
 
-	_data := make(map[uint64]map[string]string)
+	var (
+		_data  = make(map[uint64]map[string]string)
+		titles = UniqueTitles(r.GetTitle())
+	)
 
 	for rowNumber, row := range r.ToList() {
-		// if len(r.GetTitle()) != len(row) {
-		// 	panic(fmt.Errorf("表头数量与实际数据列不匹配（第%d行）", rowNumber))
-		// }
-
 		_row := make(map[string]string)
-		for _, title := range r.GetTitle() {
+		for _, title := range titles {
 			_row[title] = defaultValue
 		}
 		for k, v := range row {
-			_row[r.GetTitle()[k]] = v
+			if k < len(titles) {
+				_row[titles[k]] = v
+			}
 		}
 		_data[rowNumber] = make(map[string]string)
 		_data[rowNumber] = _row
@@ -80,6 +129,22 @@ func (r *ExcelReader) ToMap(defaultValue string) map[uint64]map[string]string {
 	return _data
 }
 
+// GetRowNumbers 获取全部数据行号（升序）
+func (r *ExcelReader) GetRowNumbers() []uint64 {
+	rowNumbers := make([]uint64, 0, len(r.data))
+	for rowNumber := range r.data {
+		rowNumbers = append(rowNumbers, rowNumber)
+	}
+	sort.Slice(rowNumbers, func(i, j int) bool { return rowNumbers[i] < rowNumbers[j] })
+
+	return rowNumbers
+}
+
+// GetExcelRowNumber 根据数据行号（ToList的键）获取Excel中的实际行号
+func (r *ExcelReader) GetExcelRowNumber(rowNumber uint64) uint64 {
+	return rowNumber + uint64(r.GetOriginalRow())
+}
+
 // SetDataByRow 设置单行数据
 func (r *ExcelReader) SetDataByRow(rowNumber uint64, data []string) *ExcelReader {
 	r.data[rowNumber+1] = data
@@ -94,6 +159,7 @@ func (r *ExcelReader) GetSheetName() string {
 // SetSheetName 设置工作表名称
 func (r *ExcelReader) SetSheetName(sheetName string) *ExcelReader {
 	r.sheetName = sheetName
+	r.mergeOrigins = nil
 	return r
 }
 
@@ -135,31 +201,78 @@ func (r *ExcelReader) GetTitle() []string {
 	return r.titles
 }
 
-// SetTitle 设置表头
+// SetTitle 设置表头（设置了表头映射时按映射转换为标准名称）
 func (r *ExcelReader) SetTitle(titles []string) *ExcelReader {
 	if len(titles) == 0 {
-		panic(errors.New("表头不能为空"))
+		return r.fail(ErrTitleEmpty)
+	}
+	r.rawTitles = titles
+	if r.header == nil {
+		r.titles = titles
+		return r
+	}
+
+	r.titles, r.report = r.header.mapTitles(titles)
+	if missing := r.report.GetMissingTitles(); len(missing) > 0 {
+		return r.fail(fmt.Errorf("%w：%s", ErrTitleRequired, strings.Join(missing, "、")))
 	}
-	r.titles = titles
 	return r
 }
 
-// OpenFile 打开文件
+// GetRawTitle 获取原始表头（未经映射）
+func (r *ExcelReader) GetRawTitle() []string {
+	return r.rawTitles
+}
+
+// OpenFile 打开文件（.csv、.tsv文件按CSV读取）
 func (r *ExcelReader) OpenFile(filename string, more ...any) *ExcelReader {
+	filename = fmt.Sprintf(filename, more...)
+	if filename == "" {
+		return r.fail(ErrFilenameEmpty)
+	}
+	if IsCSVFile(filename) {
+		return r.OpenCSV("%s", filename)
+	}
+	return r.open(func(opts ...excelize.Options) (*excelize.File, error) { return excelize.OpenFile(filename, opts...) }, true)
+}
+
+// OpenFileStream 打开文件（流式读取用，文件保持打开，读取完成后需调用Close）
+func (r *ExcelReader) OpenFileStream(filename string, more ...any) *ExcelReader {
+	filename = fmt.Sprintf(filename, more...)
 	if filename == "" {
-		panic(errors.New("文件名不能为空"))
+		return r.fail(ErrFilenameEmpty)
 	}
-	f, err := excelize.OpenFile(fmt.Sprintf(filename, more...))
+	return r.open(func(opts ...excelize.Options) (*excelize.File, error) { return excelize.OpenFile(filename, opts...) }, false)
+}
+
+// OpenReader 从io.Reader打开（如上传文件流）
+func (r *ExcelReader) OpenReader(reader io.Reader) *ExcelReader {
+	return r.open(func(opts ...excelize.Options) (*excelize.File, error) { return excelize.OpenReader(reader, opts...) }, true)
+}
+
+// open 打开文件
+func (r *ExcelReader) open(opener func(opts ...excelize.Options) (*excelize.File, error), closeFile bool) *ExcelReader {
+	if r.failed() {
+		return r
+	}
+
+	var opts []excelize.Options
+	if closeFile {
+		// 非流式读取时将工作表全部解压到内存，避免关闭文件时临时文件被清理导致大文件无法读取
+		opts = append(opts, excelize.Options{UnzipSizeLimit: excelize.UnzipSizeLimit, UnzipXMLSizeLimit: excelize.UnzipSizeLimit})
+	}
+	f, err := opener(opts...)
 	if err != nil {
-		panic(fmt.Errorf("打开文件错误：%s", err.Error()))
+		return r.fail(fmt.Errorf("打开文件错误：%w", err))
 	}
 	r.excel = f
+	r.mergeOrigins = nil
 
-	defer func() {
-		if err := r.excel.Close(); err != nil {
-			panic(errors.New("文件关闭错误"))
+	if closeFile {
+		if err = r.Close(); err != nil {
+			return r.fail(err)
 		}
-	}()
+	}
 
 	r.SetTitleRow(1)
 	r.SetOriginalRow(2)
@@ -168,39 +281,103 @@ func (r *ExcelReader) OpenFile(filename string, more ...any) *ExcelReader {
 	return r
 }
 
+// Close 关闭文件（清理临时文件）
+func (r *ExcelReader) Close() error {
+	if r.excel == nil {
+		return nil
+	}
+	if err := r.excel.Close(); err != nil {
+		return fmt.Errorf("文件关闭错误：%w", err)
+	}
+	return nil
+}
+
+// checkSheet 检查文件及工作表
+func (r *ExcelReader) checkSheet() error {
+	if r.excel == nil {
+		return ErrFileNotOpened
+	}
+	if r.GetSheetName() == "" {
+		return ErrSheetNameNotSet
+	}
+	if sheetIndex, err := r.getSheetIndex(r.GetSheetName()); err != nil || sheetIndex == -1 {
+		return fmt.Errorf("%w：%s", ErrSheetNotFound, r.GetSheetName())
+	}
+	return nil
+}
+
+// getSheetIndex 获取工作表编号（不存在时为-1）
+func (r *ExcelReader) getSheetIndex(sheetName string) (int, error) {
+	if r.excel == nil {
+		return -1, ErrFileNotOpened
+	}
+	return r.excel.GetSheetIndex(sheetName)
+}
+
+// getRows 读取工作表全部行（开启SetFillMerged时填充合并单元格）
+func (r *ExcelReader) getRows() ([][]string, error) {
+	if err := r.checkSheet(); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.excel.GetRows(r.GetSheetName())
+	if err != nil {
+		return nil, fmt.Errorf("读取数据错误：%w", err)
+	}
+	if r.fillMerged {
+		return r.fillMergeCells(rows)
+	}
+
+	return rows, nil
+}
+
+// sliceRows 根据起始行、终止行截取数据
+func (r *ExcelReader) sliceRows(rows [][]string, originalRow int) [][]string {
+	finishedRow := len(rows)
+	if r.finishedRow > 0 && r.finishedRow < finishedRow {
+		finishedRow = r.finishedRow
+	}
+	if originalRow < 0 {
+		originalRow = 0
+	}
+	if originalRow >= finishedRow {
+		return [][]string{}
+	}
+
+	return rows[originalRow:finishedRow]
+}
+
 // ReadTitle 读取表头
 func (r *ExcelReader) ReadTitle() *ExcelReader {
-	if r.GetSheetName() == "" {
-		panic(errors.New("未设置工作表名称"))
+	if r.failed() {
+		return r
 	}
 
-	if rows, err := r.excel.GetRows(r.GetSheetName()); err != nil {
-		panic(fmt.Errorf("读取表头错误：%s", err.Error()))
-	} else {
-		r.SetTitle(rows[r.GetTitleRow()])
+	rows, err := r.getRows()
+	if err != nil {
+		return r.fail(err)
+	}
+	if r.GetTitleRow() < 0 || r.GetTitleRow() >= len(rows) {
+		return r.fail(fmt.Errorf("%w：第%d行", ErrTitleRowMissing, r.GetTitleRow()+1))
 	}
 
-	return r
+	return r.SetTitle(rows[r.GetTitleRow()])
 }
 
 // Read 读取Excel
 func (r *ExcelReader) Read() *ExcelReader {
-	if r.GetSheetName() == "" {
-		panic(errors.New("未设置工作表名称"))
+	if r.failed() {
+		return r
 	}
 
-	if rows, err := r.excel.GetRows(r.GetSheetName()); err != nil {
-		panic(errors.New("读取数据错误：%s"))
-	} else {
-		if r.finishedRow == 0 {
-			r.content = rows[r.GetOriginalRow():]
-		} else {
-			r.content = rows[r.GetOriginalRow():r.GetFinishedRow()]
-		}
+	rows, err := r.getRows()
+	if err != nil {
+		return r.fail(err)
+	}
 
-		for rowNumber, row := range r.content {
-			r.SetDataByRow(uint64(rowNumber), row)
-		}
+	r.content = r.sliceRows(rows, r.GetOriginalRow())
+	for rowNumber, row := range r.content {
+		r.SetDataByRow(uint64(rowNumber), row)
 	}
 
 	return r
@@ -218,24 +395,18 @@ func (r *ExcelReader) ToDataFrameDefaultType() dataframe.DataFrame {
 
 // ToDataFrame 获取DataFrame类型数据
 func (r *ExcelReader) ToDataFrame(titleWithType map[string]series.Type) dataframe.DataFrame {
-	if r.GetSheetName() == "" {
-		panic(errors.New("未设置工作表名称"))
+	if r.failed() {
+		return dataframe.DataFrame{Err: r.err}
 	}
 
-	var _content [][]string
-
-	if rows, err := r.excel.GetRows(r.GetSheetName()); err != nil {
-		panic(errors.New("读取数据错误"))
-	} else {
-		if r.finishedRow == 0 {
-			_content = rows[r.GetTitleRow():]
-		} else {
-			_content = rows[r.GetTitleRow():r.GetFinishedRow()]
-		}
+	rows, err := r.getRows()
+	if err != nil {
+		r.fail(err)
+		return dataframe.DataFrame{Err: err}
 	}
 
 	return dataframe.LoadRecords(
-		_content,
+		r.sliceRows(rows, r.GetTitleRow()),
 		dataframe.DetectTypes(false),
 		dataframe.DefaultType(series.String),
 		dataframe.WithTypes(titleWithType),
@@ -244,24 +415,18 @@ func (r *ExcelReader) ToDataFrame(titleWithType map[string]series.Type) datafram
 
 // ToDataFrameDetectType 获取DataFrame类型数据 通过自动探寻数据类型
 func (r *ExcelReader) ToDataFrameDetectType() dataframe.DataFrame {
-	if r.GetSheetName() == "" {
-		panic(errors.New("未设置工作表名称"))
+	if r.failed() {
+		return dataframe.DataFrame{Err: r.err}
 	}
 
-	var _content [][]string
-
-	if rows, err := r.excel.GetRows(r.GetSheetName()); err != nil {
-		panic(errors.New("读取数据错误"))
-	} else {
-		if r.finishedRow == 0 {
-			_content = rows[r.GetTitleRow():]
-		} else {
-			_content = rows[r.GetTitleRow():r.GetFinishedRow()]
-		}
+	rows, err := r.getRows()
+	if err != nil {
+		r.fail(err)
+		return dataframe.DataFrame{Err: err}
 	}
 
 	return dataframe.LoadRecords(
-		_content,
+		r.sliceRows(rows, r.GetTitleRow()),
 		dataframe.DetectTypes(true),
 		dataframe.DefaultType(series.String),
 	)
@@ -0,0 +1,197 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+type (
+	// ExcelHeader 表头映射（标准名称及其别名，匹配时忽略空白、全半角及大小写）
+	ExcelHeader struct {
+		titles   []string
+		aliases  map[string]string
+		required map[string]bool
+		fuzzy    bool
+	}
+
+	// ExcelHeaderReport 表头映射报告
+	ExcelHeaderReport struct {
+		mapped          map[string]string
+		unknownTitles   []string
+		missingTitles   []string
+		duplicateTitles []string
+	}
+)
+
+// NewExcelHeader 构造函数
+func NewExcelHeader() *ExcelHeader {
+	return &ExcelHeader{aliases: make(map[string]string), required: make(map[string]bool)}
+}
+
+// AddTitle 增加标准名称及别名
+//
+//	NewExcelHeader().AddTitle("手机号", "手机号码", "联系电话").AddTitle("姓名", "名字")
+func (r *ExcelHeader) AddTitle(title string, aliases ...string) *ExcelHeader {
+	if _, exist := r.aliases[NormalizeTitle(title)]; !exist {
+		r.titles = append(r.titles, title)
+	}
+	r.aliases[NormalizeTitle(title)] = title
+	for _, alias := range aliases {
+		r.aliases[NormalizeTitle(alias)] = title
+	}
+	return r
+}
+
+// AddRequiredTitle 增加必需的标准名称及别名（表头中缺失时报错）
+func (r *ExcelHeader) AddRequiredTitle(title string, aliases ...string) *ExcelHeader {
+	r.required[title] = true
+	return r.AddTitle(title, aliases...)
+}
+
+// SetFuzzy 设置模糊匹配（未精确匹配时，表头包含标准名称或别名即视为匹配，取最长者）
+func (r *ExcelHeader) SetFuzzy(fuzzy bool) *ExcelHeader {
+	r.fuzzy = fuzzy
+	return r
+}
+
+// match 匹配标准名称
+func (r *ExcelHeader) match(title string) (string, bool) {
+	normalized := NormalizeTitle(title)
+	if normalized == "" {
+		return "", false
+	}
+	if canonical, exist := r.aliases[normalized]; exist {
+		return canonical, true
+	}
+	if !r.fuzzy {
+		return "", false
+	}
+
+	var matched, canonical string
+	for alias, title := range r.aliases {
+		if strings.Contains(normalized, alias) && (len(alias) > len(matched) || len(alias) == len(matched) && title < canonical) {
+			matched, canonical = alias, title
+		}
+	}
+	return canonical, matched != ""
+}
+
+// mapTitles 将原始表头转换为标准名称（未匹配的保持原样）
+func (r *ExcelHeader) mapTitles(titles []string) ([]string, *ExcelHeaderReport) {
+	var (
+		mapped = make([]string, len(titles))
+		report = &ExcelHeaderReport{mapped: make(map[string]string)}
+		found  = make(map[string]bool)
+	)
+
+	for idx, title := range titles {
+		canonical, ok := r.match(title)
+		if !ok {
+			mapped[idx] = title
+			if strings.TrimSpace(title) != "" {
+				report.unknownTitles = append(report.unknownTitles, title)
+			}
+			continue
+		}
+
+		mapped[idx] = canonical
+		if found[canonical] {
+			report.duplicateTitles = append(report.duplicateTitles, title)
+			continue
+		}
+		found[canonical] = true
+		report.mapped[title] = canonical
+	}
+
+	for _, title := range r.titles {
+		if r.required[title] && !found[title] {
+			report.missingTitles = append(report.missingTitles, title)
+		}
+	}
+
+	return mapped, report
+}
+
+// GetMapped 获取已匹配的表头（原始表头→标准名称）
+func (r *ExcelHeaderReport) GetMapped() map[string]string {
+	return r.mapped
+}
+
+// GetUnknownTitles 获取未匹配的表头
+func (r *ExcelHeaderReport) GetUnknownTitles() []string {
+	return r.unknownTitles
+}
+
+// GetMissingTitles 获取缺失的必需表头
+func (r *ExcelHeaderReport) GetMissingTitles() []string {
+	return r.missingTitles
+}
+
+// GetDuplicateTitles 获取重复的表头（映射到已出现的标准名称）
+func (r *ExcelHeaderReport) GetDuplicateTitles() []string {
+	return r.duplicateTitles
+}
+
+// IsValid 是否不缺少必需表头
+func (r *ExcelHeaderReport) IsValid() bool {
+	return len(r.missingTitles) == 0
+}
+
+// GetHeader 获取表头映射
+func (r *ExcelReader) GetHeader() *ExcelHeader {
+	return r.header
+}
+
+// SetHeader 设置表头映射（须在读取表头之前设置）
+func (r *ExcelReader) SetHeader(header *ExcelHeader) *ExcelReader {
+	r.header = header
+	return r
+}
+
+// GetHeaderReport 获取表头映射报告（未设置表头映射时为nil）
+func (r *ExcelReader) GetHeaderReport() *ExcelHeaderReport {
+	return r.report
+}
+
+// NormalizeTitle 标准化表头（全角转半角、去除空白、转小写）
+func NormalizeTitle(title string) string {
+	return strings.Map(func(char rune) rune {
+		if unicode.IsSpace(char) {
+			return -1
+		}
+		return unicode.ToLower(char)
+	}, width.Fold.String(title))
+}
+
+// UniqueTitles 表头去重（重复的表头依次追加序号，如：备注、备注_2、备注_3）
+func UniqueTitles(titles []string) []string {
+	var (
+		unique = make([]string, len(titles))
+		used   = make(map[string]bool, len(titles))
+		counts = make(map[string]int, len(titles))
+	)
+	for _, title := range titles {
+		used[title] = true
+	}
+
+	for idx, title := range titles {
+		counts[title]++
+		if counts[title] == 1 {
+			unique[idx] = title
+			continue
+		}
+		for {
+			unique[idx] = fmt.Sprintf("%s_%d", title, counts[title])
+			if !used[unique[idx]] {
+				break
+			}
+			counts[title]++
+		}
+		used[unique[idx]] = true
+	}
+
+	return unique
+}
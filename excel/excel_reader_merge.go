@@ -0,0 +1,183 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ExcelTitleSeparator 多行表头组合时的默认分隔符
+const ExcelTitleSeparator = "/"
+
+// GetFillMerged 获取是否填充合并单元格
+func (r *ExcelReader) GetFillMerged() bool {
+	return r.fillMerged
+}
+
+// SetFillMerged 设置是否填充合并单元格（开启后合并区域内的每个单元格均读取为左上角单元格的值，流式读取不支持）
+func (r *ExcelReader) SetFillMerged(fillMerged bool) *ExcelReader {
+	r.fillMerged = fillMerged
+	return r
+}
+
+// GetTitleSeparator 获取多行表头分隔符
+func (r *ExcelReader) GetTitleSeparator() string {
+	if r.titleSeparator == "" {
+		return ExcelTitleSeparator
+	}
+	return r.titleSeparator
+}
+
+// SetTitleSeparator 设置多行表头分隔符（默认为/）
+func (r *ExcelReader) SetTitleSeparator(titleSeparator string) *ExcelReader {
+	r.titleSeparator = titleSeparator
+	return r
+}
+
+// GetMergeCells 获取当前工作表的合并区域
+func (r *ExcelReader) GetMergeCells() ([]excelize.MergeCell, error) {
+	if err := r.checkSheet(); err != nil {
+		return nil, err
+	}
+
+	mergeCells, err := r.excel.GetMergeCells(r.GetSheetName())
+	if err != nil {
+		return nil, fmt.Errorf("读取合并单元格错误：%w", err)
+	}
+
+	return mergeCells, nil
+}
+
+// ReadCompositeTitle 读取多行表头（startRow至endRow行，行号从1开始），合并单元格按所在区域填充后，
+// 每列自上而下以分隔符组合（相邻重复的部分只保留一个，如：收入/一季度），并从表头下一行开始读取
+func (r *ExcelReader) ReadCompositeTitle(startRow, endRow int) *ExcelReader {
+	if r.failed() {
+		return r
+	}
+
+	rows, err := r.getRows()
+	if err != nil {
+		return r.fail(err)
+	}
+	if !r.fillMerged {
+		if rows, err = r.fillMergeCells(rows); err != nil {
+			return r.fail(err)
+		}
+	}
+	if startRow < 1 || endRow < startRow || endRow > len(rows) {
+		return r.fail(fmt.Errorf("%w：第%d至%d行", ErrTitleRowMissing, startRow, endRow))
+	}
+
+	colCount := 0
+	for _, row := range rows[startRow-1 : endRow] {
+		if len(row) > colCount {
+			colCount = len(row)
+		}
+	}
+
+	titles := make([]string, colCount)
+	for col := range titles {
+		var parts []string
+		for _, row := range rows[startRow-1 : endRow] {
+			if col >= len(row) {
+				continue
+			}
+			part := strings.TrimSpace(row[col])
+			if part != "" && (len(parts) == 0 || parts[len(parts)-1] != part) {
+				parts = append(parts, part)
+			}
+		}
+		titles[col] = strings.Join(parts, r.GetTitleSeparator())
+	}
+
+	return r.SetTitle(titles).SetTitleRow(endRow).SetOriginalRow(endRow + 1)
+}
+
+// fillMergeCells 将合并区域左上角单元格的值填充到区域内的每个单元格
+func (r *ExcelReader) fillMergeCells(rows [][]string) ([][]string, error) {
+	mergeCells, err := r.GetMergeCells()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mergeCell := range mergeCells {
+		startCol, startRow, err := excelize.CellNameToCoordinates(mergeCell.GetStartAxis())
+		if err != nil {
+			return nil, fmt.Errorf("读取合并单元格错误：%w", err)
+		}
+		endCol, endRow, err := excelize.CellNameToCoordinates(mergeCell.GetEndAxis())
+		if err != nil {
+			return nil, fmt.Errorf("读取合并单元格错误：%w", err)
+		}
+
+		var value string
+		if startRow <= len(rows) && startCol <= len(rows[startRow-1]) {
+			value = rows[startRow-1][startCol-1]
+		}
+		if value == "" {
+			continue
+		}
+
+		for len(rows) < endRow {
+			rows = append(rows, nil)
+		}
+		for row := startRow; row <= endRow; row++ {
+			for len(rows[row-1]) < endCol {
+				rows[row-1] = append(rows[row-1], "")
+			}
+			for col := startCol; col <= endCol; col++ {
+				rows[row-1][col-1] = value
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+// mergeOrigin 获取单元格所在合并区域的左上角单元格（不在合并区域内时返回自身，合并区域首次使用时按工作表建立索引）
+func (r *ExcelReader) mergeOrigin(coordinate string) (string, error) {
+	col, row, err := excelize.CellNameToCoordinates(coordinate)
+	if err != nil {
+		return "", err
+	}
+	if r.mergeOrigins == nil {
+		if r.mergeOrigins, err = r.buildMergeOrigins(); err != nil {
+			return "", err
+		}
+	}
+
+	if origin, exist := r.mergeOrigins[NewExcelCellRef(col, row)]; exist {
+		return origin, nil
+	}
+	return coordinate, nil
+}
+
+// buildMergeOrigins 建立当前工作表合并区域内单元格到左上角单元格的索引（不含左上角单元格自身）
+func (r *ExcelReader) buildMergeOrigins() (map[ExcelCellRef]string, error) {
+	mergeCells, err := r.GetMergeCells()
+	if err != nil {
+		return nil, err
+	}
+
+	origins := make(map[ExcelCellRef]string)
+	for _, mergeCell := range mergeCells {
+		startCol, startRow, err := excelize.CellNameToCoordinates(mergeCell.GetStartAxis())
+		if err != nil {
+			return nil, err
+		}
+		endCol, endRow, err := excelize.CellNameToCoordinates(mergeCell.GetEndAxis())
+		if err != nil {
+			return nil, err
+		}
+		for row := startRow; row <= endRow; row++ {
+			for col := startCol; col <= endCol; col++ {
+				if col != startCol || row != startRow {
+					origins[NewExcelCellRef(col, row)] = mergeCell.GetStartAxis()
+				}
+			}
+		}
+	}
+
+	return origins, nil
+}
@@ -0,0 +1,47 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestGetCellValueFillMerged(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "merge.xlsx")
+	f := excelize.NewFile()
+	if _, err := f.NewSheet("Sheet2"); err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range []struct{ sheet, start, end, value string }{
+		{sheet: "Sheet1", start: "A1", end: "B2", value: "合并1"},
+		{sheet: "Sheet2", start: "B2", end: "C3", value: "合并2"},
+	} {
+		if err := f.SetCellValue(item.sheet, item.start, item.value); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.MergeCell(item.sheet, item.start, item.end); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.SaveAs(filename); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	reader := NewExcelReader().SetCollectErr(true).OpenFile(filename).SetFillMerged(true)
+	for _, tt := range []struct{ sheet, coordinate, want string }{
+		{sheet: "Sheet1", coordinate: "B2", want: "合并1"},
+		{sheet: "Sheet1", coordinate: "C3", want: ""},
+		{sheet: "Sheet2", coordinate: "C3", want: "合并2"},
+		{sheet: "Sheet2", coordinate: "B1", want: ""},
+	} {
+		cell, err := reader.SetSheetName(tt.sheet).GetCellValue(tt.coordinate)
+		if err != nil {
+			t.Fatalf("GetCellValue(%s!%s): %v", tt.sheet, tt.coordinate, err)
+		}
+		if cell.GetText() != tt.want {
+			t.Errorf("GetCellValue(%s!%s): got %q, want %q", tt.sheet, tt.coordinate, cell.GetText(), tt.want)
+		}
+	}
+}
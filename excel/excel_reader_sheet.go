@@ -0,0 +1,131 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GetSheetList 获取全部工作表名称
+func (r *ExcelReader) GetSheetList() []string {
+	if r.excel == nil {
+		return nil
+	}
+	return r.excel.GetSheetList()
+}
+
+// SetSheetIndex 设置工作表（根据编号，从0开始）
+func (r *ExcelReader) SetSheetIndex(sheetIndex int) *ExcelReader {
+	if r.failed() {
+		return r
+	}
+	if r.excel == nil {
+		return r.fail(ErrFileNotOpened)
+	}
+	if sheetIndex < 0 {
+		return r.fail(ErrSheetIndexInvalid)
+	}
+
+	sheetList := r.GetSheetList()
+	if sheetIndex >= len(sheetList) {
+		return r.fail(fmt.Errorf("%w：%d", ErrSheetNotFound, sheetIndex))
+	}
+
+	return r.SetSheetName(sheetList[sheetIndex])
+}
+
+// SetActiveSheet 设置工作表为文件中的活动工作表
+func (r *ExcelReader) SetActiveSheet() *ExcelReader {
+	if r.failed() {
+		return r
+	}
+	if r.excel == nil {
+		return r.fail(ErrFileNotOpened)
+	}
+
+	return r.SetSheetName(r.excel.GetSheetName(r.excel.GetActiveSheetIndex()))
+}
+
+// detectTitleRow 查找第一个非空行（从0开始，没有时返回-1）
+func detectTitleRow(rows [][]string) int {
+	for rowIdx, row := range rows {
+		for _, content := range row {
+			if strings.TrimSpace(content) != "" {
+				return rowIdx
+			}
+		}
+	}
+	return -1
+}
+
+// DetectTitleRow 自动识别表头行（第一个非空行），并从表头下一行开始读取
+func (r *ExcelReader) DetectTitleRow() *ExcelReader {
+	if r.failed() {
+		return r
+	}
+
+	rows, err := r.getRows()
+	if err != nil {
+		return r.fail(err)
+	}
+
+	titleRow := detectTitleRow(rows)
+	if titleRow == -1 {
+		return r.fail(fmt.Errorf("%w：%s", ErrTitleRowMissing, r.GetSheetName()))
+	}
+
+	return r.SetTitleRow(titleRow + 1).SetOriginalRow(titleRow + 2)
+}
+
+// ReadAll 读取全部工作表：每个工作表返回一个独立的读取器（按工作表顺序），默认自动识别表头行，
+// 可通过configure根据工作表名称单独设置表头行、起始行、终止行；空工作表返回没有数据的读取器；
+// 表头映射、校验规则、公式计算、合并单元格填充、CSV等配置沿用当前读取器
+func (r *ExcelReader) ReadAll(configure func(reader *ExcelReader)) []*ExcelReader {
+	if r.failed() {
+		return nil
+	}
+	if r.excel == nil {
+		r.fail(ErrFileNotOpened)
+		return nil
+	}
+
+	readers := make([]*ExcelReader, 0, len(r.GetSheetList()))
+	for _, sheetName := range r.GetSheetList() {
+		reader := &ExcelReader{
+			excel:          r.excel,
+			sheetName:      sheetName,
+			header:         r.header,
+			rules:          append([]*ExcelRule{}, r.rules...),
+			calcFormula:    r.calcFormula,
+			fillMerged:     r.fillMerged,
+			titleSeparator: r.titleSeparator,
+			csvDelimiter:   r.csvDelimiter,
+			csvEncoding:    r.csvEncoding,
+			collectErr:     r.collectErr,
+			data:           make(map[uint64][]string),
+		}
+		readers = append(readers, reader)
+
+		rows, err := reader.getRows()
+		if err != nil {
+			reader.fail(err)
+			continue
+		}
+
+		titleRow := detectTitleRow(rows)
+		if titleRow == -1 {
+			titleRow = 0
+		}
+		reader.SetTitleRow(titleRow + 1).SetOriginalRow(titleRow + 2)
+
+		if configure != nil {
+			configure(reader)
+		}
+
+		if reader.GetTitleRow() < len(rows) && len(rows[reader.GetTitleRow()]) > 0 {
+			reader.ReadTitle()
+		}
+		reader.Read()
+	}
+
+	return readers
+}
@@ -0,0 +1,54 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestReadAllInheritsConfiguration(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "sheets.xlsx")
+	writer := NewExcelWriter(filename).SetCollectErr(true).ActiveSheetByIndex(0).SetTitleRow([]string{"名字", "数量"}, 1).
+		AddRow(NewExcelRow().SetRowNumber(2).SetCells([]*ExcelCell{NewExcelCellAny("a"), NewExcelCellInt(1)})).
+		CreateSheet("Sheet2").SetTitleRow([]string{"姓名", "数量"}, 1).
+		AddRow(NewExcelRow().SetRowNumber(2).SetCells([]*ExcelCell{NewExcelCellAny("b"), NewExcelCellInt(2)}))
+	if err := writer.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := writer.Err(); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	rule := NewExcelRule("名称").SetRequired(true)
+	reader := NewExcelReader().SetCollectErr(true).OpenFile(filename).
+		SetHeader(NewExcelHeader().AddTitle("名称", "名字", "姓名")).
+		SetRules(rule).
+		SetCalcFormula(true).
+		SetFillMerged(true).
+		SetCSVDelimiter(';').
+		SetCSVEncoding(ExcelCSVEncodingGBK)
+
+	readers := reader.ReadAll(nil)
+	if err := reader.Err(); err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if len(readers) != 2 {
+		t.Fatalf("ReadAll: got %d readers, want 2", len(readers))
+	}
+	for _, sheetReader := range readers {
+		if err := sheetReader.Err(); err != nil {
+			t.Fatalf("%s: %v", sheetReader.GetSheetName(), err)
+		}
+		if got := sheetReader.GetTitle(); len(got) == 0 || got[0] != "名称" {
+			t.Errorf("%s title: got %v, want 名称 mapped by header", sheetReader.GetSheetName(), got)
+		}
+		if got := sheetReader.GetRules(); len(got) != 1 || got[0] != rule {
+			t.Errorf("%s rules: got %v", sheetReader.GetSheetName(), got)
+		}
+		if !sheetReader.GetCalcFormula() || !sheetReader.GetFillMerged() {
+			t.Errorf("%s: calcFormula or fillMerged not inherited", sheetReader.GetSheetName())
+		}
+		if sheetReader.GetCSVDelimiter() != ';' || sheetReader.GetCSVEncoding() != ExcelCSVEncodingGBK {
+			t.Errorf("%s: CSV options not inherited", sheetReader.GetSheetName())
+		}
+	}
+}
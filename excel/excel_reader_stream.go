@@ -0,0 +1,149 @@
+package excel
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ExcelRowIterator Excel行迭代器（流式读取，不会将整个工作表载入内存）
+type ExcelRowIterator struct {
+	reader    *ExcelReader
+	rows      *excelize.Rows
+	rowIndex  int
+	rowNumber uint64
+	row       []string
+	err       error
+	done      bool
+}
+
+// Iterator 获取行迭代器（遵循SetOriginalRow、SetFinishedRow、SetTitleRow，未设置表头时在经过表头行时自动读取）
+//
+//	iterator := reader.Iterator()
+//	defer iterator.Close()
+//	for iterator.Next() {
+//		iterator.Row()
+//	}
+//	if err := iterator.Err(); err != nil {}
+func (r *ExcelReader) Iterator() *ExcelRowIterator {
+	iterator := &ExcelRowIterator{reader: r}
+
+	if r.failed() {
+		iterator.err, iterator.done = r.err, true
+		return iterator
+	}
+
+	if err := r.checkSheet(); err != nil {
+		r.fail(err)
+		iterator.err, iterator.done = err, true
+		return iterator
+	}
+
+	rows, err := r.excel.Rows(r.GetSheetName())
+	if err != nil {
+		err = fmt.Errorf("读取数据错误：%w", err)
+		r.fail(err)
+		iterator.err, iterator.done = err, true
+		return iterator
+	}
+	iterator.rows = rows
+
+	return iterator
+}
+
+// Next 移动到下一行数据，没有更多数据或出错时返回false
+func (r *ExcelRowIterator) Next() bool {
+	if r.done {
+		return false
+	}
+
+	for r.rows.Next() {
+		rowIndex := r.rowIndex
+		r.rowIndex++
+
+		if r.reader.finishedRow > 0 && rowIndex >= r.reader.finishedRow {
+			break
+		}
+
+		if rowIndex == r.reader.GetTitleRow() && len(r.reader.GetTitle()) == 0 {
+			titles, err := r.rows.Columns()
+			if err != nil {
+				return r.stop(fmt.Errorf("读取表头错误：%w", err))
+			}
+			if len(titles) > 0 {
+				r.reader.SetTitle(titles)
+			}
+		}
+
+		if rowIndex < r.reader.GetOriginalRow() {
+			continue
+		}
+
+		row, err := r.rows.Columns()
+		if err != nil {
+			return r.stop(fmt.Errorf("读取数据错误：%w", err))
+		}
+		r.row = row
+		r.rowNumber = uint64(rowIndex-r.reader.GetOriginalRow()) + 1
+
+		return true
+	}
+
+	if err := r.rows.Error(); err != nil {
+		return r.stop(fmt.Errorf("读取数据错误：%w", err))
+	}
+
+	r.done = true
+	return false
+}
+
+// stop 记录错误并终止迭代
+func (r *ExcelRowIterator) stop(err error) bool {
+	r.err, r.done = err, true
+	if r.reader.err == nil {
+		r.reader.err = err
+	}
+	return false
+}
+
+// Row 获取当前行数据
+func (r *ExcelRowIterator) Row() []string {
+	return r.row
+}
+
+// RowNumber 获取当前行号（与ToList的键一致，从读取起始行开始计数，从1开始）
+func (r *ExcelRowIterator) RowNumber() uint64 {
+	return r.rowNumber
+}
+
+// Err 获取错误
+func (r *ExcelRowIterator) Err() error {
+	return r.err
+}
+
+// Close 关闭迭代器（提前终止时也需调用）
+func (r *ExcelRowIterator) Close() error {
+	r.done = true
+	if r.rows == nil {
+		return nil
+	}
+	return r.rows.Close()
+}
+
+// Stream 流式逐行读取，fn返回false时提前终止
+func (r *ExcelReader) Stream(fn func(rowNumber uint64, row []string) bool) *ExcelReader {
+	iterator := r.Iterator()
+	defer func() { _ = iterator.Close() }()
+
+	for iterator.Next() {
+		if !fn(iterator.RowNumber(), iterator.Row()) {
+			break
+		}
+	}
+
+	if err := iterator.Err(); err != nil && !r.collectErr {
+		panic(err)
+	}
+
+	return r
+}
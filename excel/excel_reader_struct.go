@@ -0,0 +1,122 @@
+package excel
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ReadInto 获取数据（结构体类型），字段通过`excel:"标题"`标签与表头对应
+func ReadInto[T any](reader *ExcelReader) ([]T, []*CellError) {
+	var data []T
+	errs := reader.ToStructs(&data)
+	return data, errs
+}
+
+// ToStructs 获取数据（结构体类型），dst必须为结构体切片指针，转换失败的单元格保留零值并记录错误
+func (r *ExcelReader) ToStructs(dst any) []*CellError {
+	if r.failed() {
+		return nil
+	}
+	if len(r.GetTitle()) == 0 {
+		r.fail(ErrTitleNotSet)
+		return nil
+	}
+
+	sliceValue := reflect.ValueOf(dst)
+	if sliceValue.Kind() != reflect.Pointer || sliceValue.Elem().Kind() != reflect.Slice {
+		r.fail(errors.New("目标必须为切片指针"))
+		return nil
+	}
+	sliceValue = sliceValue.Elem()
+
+	var (
+		elemType   = sliceValue.Type().Elem()
+		structType = elemType
+		errs       []*CellError
+		columns    = make(map[string]int)
+	)
+
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		r.fail(errors.New("切片元素必须为结构体"))
+		return nil
+	}
+
+	for idx, title := range r.GetTitle() {
+		if _, exist := columns[title]; !exist {
+			columns[title] = idx
+		}
+	}
+
+	fields := structFields(structType)
+
+	for _, rowNumber := range r.GetRowNumbers() {
+		var (
+			row  = r.ToList()[rowNumber]
+			elem = reflect.New(structType).Elem()
+		)
+
+		for _, field := range fields {
+			colIdx, exist := columns[field.title]
+			if !exist || colIdx >= len(row) {
+				continue
+			}
+
+			content := r.structCellContent(field, rowNumber, colIdx, row[colIdx])
+			value, err := fieldByIndexAlloc(elem, field.index)
+			if err == nil {
+				err = setFieldValue(value, content, field.tag)
+			}
+			if err != nil {
+				errs = append(errs, NewCellError(r.GetExcelRowNumber(rowNumber), colIdx+1, field.title, row[colIdx], err))
+			}
+		}
+
+		if elemType.Kind() == reflect.Pointer {
+			sliceValue.Set(reflect.Append(sliceValue, elem.Addr()))
+		} else {
+			sliceValue.Set(reflect.Append(sliceValue, elem))
+		}
+	}
+
+	return errs
+}
+
+// structCellContent 时间、时长字段优先使用单元格原始值（Excel序列号），避免显示格式造成误差（未设置layout且读取的是工作簿文件时）
+func (r *ExcelReader) structCellContent(field excelField, rowNumber uint64, colIdx int, content string) string {
+	typ := field.typ
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ != timeType && typ != durationType || field.tag.options["layout"] != "" || r.excel == nil {
+		return content
+	}
+
+	coordinate, err := excelize.CoordinatesToCellName(colIdx+1, int(r.GetExcelRowNumber(rowNumber)))
+	if err != nil {
+		return content
+	}
+	cell, err := r.GetCellValue(coordinate)
+	if err != nil || cell.GetResultType() != ExcelCellValueNumber && cell.GetResultType() != ExcelCellValueDate {
+		return content
+	}
+	if _, err = strconv.ParseFloat(cell.GetRaw(), 64); err != nil {
+		return content
+	}
+	// 时间字段在此转换序列号（ParseExcelDate不将纯数字视为序列号）
+	if typ == timeType {
+		t, err := ParseExcelSerial(cell.GetRaw())
+		if err != nil {
+			return content
+		}
+		return t.Format(time.RFC3339Nano)
+	}
+
+	return cell.GetRaw()
+}
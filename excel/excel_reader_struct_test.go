@@ -0,0 +1,138 @@
+package excel
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type durationRecord struct {
+	Name     string        `excel:"名称"`
+	Duration time.Duration `excel:"时长"`
+	Clock    time.Duration `excel:"钟点,format=h:mm"`
+	Time     time.Time     `excel:"时间,format=yyyy-mm-dd"`
+}
+
+func TestDurationRoundTrip(t *testing.T) {
+	var (
+		filename = filepath.Join(t.TempDir(), "duration.xlsx")
+		day      = time.Date(2024, 3, 5, 13, 4, 5, 0, time.Local)
+		records  = []durationRecord{
+			{Name: "30m", Duration: 30 * time.Minute, Clock: 30 * time.Minute, Time: day},
+			{Name: "90m", Duration: 90 * time.Minute, Clock: 90 * time.Minute, Time: day},
+			{Name: "25h", Duration: 25 * time.Hour, Clock: 25 * time.Hour, Time: day},
+		}
+	)
+
+	writer := NewExcelWriter(filename).SetCollectErr(true).WriteStructs(records, nil)
+	if err := writer.Err(); err != nil {
+		t.Fatalf("WriteStructs: %v", err)
+	}
+	if err := writer.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	reader := NewExcelReader().SetCollectErr(true).SetTitleRow(1).SetOriginalRow(2).AutoRead(filename)
+	if err := reader.Err(); err != nil {
+		t.Fatalf("AutoRead: %v", err)
+	}
+
+	texts := []string{"0:30:00", "1:30:00", "25:00:00"}
+	for idx, rowNumber := range reader.GetRowNumbers() {
+		if got := reader.ToList()[rowNumber][1]; got != texts[idx] {
+			t.Errorf("Read row %d: got %q, want %q", rowNumber, got, texts[idx])
+		}
+	}
+
+	data, errs := ReadInto[durationRecord](reader)
+	if len(errs) > 0 {
+		t.Fatalf("ReadInto: %v", errs)
+	}
+	if len(data) != len(records) {
+		t.Fatalf("ReadInto: got %d rows, want %d", len(data), len(records))
+	}
+	for idx, record := range records {
+		got := data[idx]
+		if got.Duration != record.Duration {
+			t.Errorf("%s duration: got %v, want %v", record.Name, got.Duration, record.Duration)
+		}
+		if got.Clock != record.Clock {
+			t.Errorf("%s clock: got %v, want %v", record.Name, got.Clock, record.Clock)
+		}
+		if !got.Time.Equal(record.Time) {
+			t.Errorf("%s time: got %v, want %v", record.Name, got.Time, record.Time)
+		}
+	}
+}
+
+func TestSetFieldValueInteger(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		kind    reflect.Kind
+		want    string
+		wantErr bool
+	}{
+		{name: "int", content: "1,234", kind: reflect.Int64, want: "1234"},
+		{name: "int float", content: "12.0", kind: reflect.Int64, want: "12"},
+		{name: "int max", content: "9223372036854775807", kind: reflect.Int64, want: "9223372036854775807"},
+		{name: "int overflow", content: "9223372036854775808", kind: reflect.Int64, wantErr: true},
+		{name: "int float overflow", content: "1e19", kind: reflect.Int64, wantErr: true},
+		{name: "int fraction", content: "1.5", kind: reflect.Int64, wantErr: true},
+		{name: "int8 overflow", content: "128", kind: reflect.Int8, wantErr: true},
+		{name: "uint max", content: "18446744073709551615", kind: reflect.Uint64, want: "18446744073709551615"},
+		{name: "uint overflow", content: "18446744073709551616", kind: reflect.Uint64, wantErr: true},
+		{name: "uint negative", content: "-1", kind: reflect.Uint64, wantErr: true},
+		{name: "uint float", content: "1e3", kind: reflect.Uint32, want: "1000"},
+	}
+
+	types := map[reflect.Kind]reflect.Type{
+		reflect.Int64:  reflect.TypeOf(int64(0)),
+		reflect.Int8:   reflect.TypeOf(int8(0)),
+		reflect.Uint64: reflect.TypeOf(uint64(0)),
+		reflect.Uint32: reflect.TypeOf(uint32(0)),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := reflect.New(types[tt.kind]).Elem()
+			err := setFieldValue(v, tt.content, excelTag{})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("setFieldValue(%q): got %v, want error", tt.content, v.Interface())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("setFieldValue(%q): %v", tt.content, err)
+			}
+			if got := fmt.Sprint(v.Interface()); got != tt.want {
+				t.Errorf("setFieldValue(%q): got %s, want %s", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+type embeddedInner struct {
+	Code string `excel:"编码"`
+}
+
+type embeddedRecord struct {
+	*embeddedInner
+	Name string `excel:"名称"`
+}
+
+func TestFieldByIndexAllocUnexportedEmbedded(t *testing.T) {
+	elem := reflect.New(reflect.TypeOf(embeddedRecord{})).Elem()
+	if _, err := fieldByIndexAlloc(elem, []int{0, 0}); err == nil {
+		t.Fatal("fieldByIndexAlloc: got nil error for unexported embedded pointer")
+	}
+	field, err := fieldByIndexAlloc(elem, []int{1})
+	if err != nil {
+		t.Fatalf("fieldByIndexAlloc: %v", err)
+	}
+	if err := setFieldValue(field, "a", excelTag{}); err != nil || elem.Interface().(embeddedRecord).Name != "a" {
+		t.Errorf("setFieldValue: got %v, %v", elem.Interface(), err)
+	}
+}
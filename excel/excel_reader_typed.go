@@ -0,0 +1,290 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// excelBuiltInNumberFormats 内置数字格式
+var excelBuiltInNumberFormats = map[int]string{
+	0:  "General",
+	1:  "0",
+	2:  "0.00",
+	3:  "#,##0",
+	4:  "#,##0.00",
+	9:  "0%",
+	10: "0.00%",
+	11: "0.00E+00",
+	12: "# ?/?",
+	13: "# ??/??",
+	14: "mm-dd-yy",
+	15: "d-mmm-yy",
+	16: "d-mmm",
+	17: "mmm-yy",
+	18: "h:mm AM/PM",
+	19: "h:mm:ss AM/PM",
+	20: "h:mm",
+	21: "h:mm:ss",
+	22: "m/d/yy h:mm",
+	37: "#,##0 ;(#,##0)",
+	38: "#,##0 ;[Red](#,##0)",
+	39: "#,##0.00;(#,##0.00)",
+	40: "#,##0.00;[Red](#,##0.00)",
+	45: "mm:ss",
+	46: "[h]:mm:ss",
+	47: "mmss.0",
+	48: "##0.0E+0",
+	49: "@",
+}
+
+// GetCalcFormula 获取是否重新计算公式
+func (r *ExcelReader) GetCalcFormula() bool {
+	return r.calcFormula
+}
+
+// SetCalcFormula 设置是否重新计算公式（默认使用文件中缓存的计算结果，缓存为空时自动计算；开启后全部通过CalcCellValue重新计算，适用于缓存已过期的文件）
+func (r *ExcelReader) SetCalcFormula(calcFormula bool) *ExcelReader {
+	r.calcFormula = calcFormula
+	return r
+}
+
+// ReadTyped 按类型读取（遵循SetOriginalRow、SetFinishedRow，结果通过ToTypedList、ToTypedMap获取）
+func (r *ExcelReader) ReadTyped() *ExcelReader {
+	if r.failed() {
+		return r
+	}
+
+	rows, err := r.getRows()
+	if err != nil {
+		return r.fail(err)
+	}
+
+	r.typedData = make(map[uint64][]*ExcelCellValue)
+	for idx, row := range r.sliceRows(rows, r.GetOriginalRow()) {
+		rowNumber := uint64(idx + 1)
+		colCount := len(row)
+		if len(r.GetTitle()) > colCount {
+			colCount = len(r.GetTitle())
+		}
+
+		cells := make([]*ExcelCellValue, colCount)
+		for col := 0; col < colCount; col++ {
+			coordinate, err := excelize.CoordinatesToCellName(col+1, int(r.GetExcelRowNumber(rowNumber)))
+			if err != nil {
+				return r.fail(err)
+			}
+			if cells[col], err = r.GetCellValue(coordinate); err != nil {
+				return r.fail(err)
+			}
+		}
+		r.typedData[rowNumber] = cells
+	}
+
+	return r
+}
+
+// ToTypedList 获取按类型读取的数据（数组类型）
+func (r *ExcelReader) ToTypedList() map[uint64][]*ExcelCellValue {
+	return r.typedData
+}
+
+// ToTypedMap 获取按类型读取的数据（map类型，重复的表头依次追加序号，缺少的列为空单元格）
+func (r *ExcelReader) ToTypedMap() map[uint64]map[string]*ExcelCellValue {
+	if len(r.GetTitle()) == 0 {
+		r.fail(ErrTitleNotSet)
+		return nil
+	}
+
+	var (
+		_data  = make(map[uint64]map[string]*ExcelCellValue)
+		titles = UniqueTitles(r.GetTitle())
+	)
+
+	for rowNumber, row := range r.ToTypedList() {
+		_row := make(map[string]*ExcelCellValue)
+		for k, title := range titles {
+			if k < len(row) {
+				_row[title] = row[k]
+			} else {
+				_row[title] = &ExcelCellValue{title: r.GetTitle()[k], valueType: ExcelCellValueEmpty, resultType: ExcelCellValueEmpty}
+			}
+		}
+		_data[rowNumber] = _row
+	}
+
+	return _data
+}
+
+// GetCellValue 按类型读取当前工作表的单元格（如：B2，开启SetFillMerged时合并区域内的单元格读取左上角单元格）
+func (r *ExcelReader) GetCellValue(coordinate string) (*ExcelCellValue, error) {
+	if err := r.checkSheet(); err != nil {
+		return nil, err
+	}
+
+	var (
+		sheetName = r.GetSheetName()
+		cell      = &ExcelCellValue{coordinate: coordinate}
+		err       error
+	)
+	wrap := func(err error) error {
+		return NewCellErrorByCoordinate(coordinate, cell.text, fmt.Errorf("读取数据错误：%w", err))
+	}
+
+	if col, _, err := excelize.CellNameToCoordinates(coordinate); err == nil && col <= len(r.GetTitle()) {
+		cell.title = r.GetTitle()[col-1]
+	}
+	if r.fillMerged {
+		if coordinate, err = r.mergeOrigin(coordinate); err != nil {
+			return nil, wrap(err)
+		}
+	}
+	if cell.text, err = r.excel.GetCellValue(sheetName, coordinate); err != nil {
+		return nil, wrap(err)
+	}
+	if cell.raw, err = r.excel.GetCellValue(sheetName, coordinate, excelize.Options{RawCellValue: true}); err != nil {
+		return nil, wrap(err)
+	}
+	if cell.formula, err = r.excel.GetCellFormula(sheetName, coordinate); err != nil {
+		return nil, wrap(err)
+	}
+	cellType, err := r.excel.GetCellType(sheetName, coordinate)
+	if err != nil {
+		return nil, wrap(err)
+	}
+	styleID, err := r.excel.GetCellStyle(sheetName, coordinate)
+	if err != nil {
+		return nil, wrap(err)
+	}
+	numberFormat, isDate := r.numberFormat(styleID)
+	cell.numberFormat = numberFormat
+
+	if cell.formula != "" && (r.calcFormula || cell.raw == "") {
+		// 计算出错（如：#DIV/0!、不支持的函数）时优先使用缓存结果，没有缓存时值为错误信息
+		result, err := r.excel.CalcCellValue(sheetName, coordinate, excelize.Options{RawCellValue: true})
+		switch {
+		case err == nil:
+			cell.raw = result
+			cell.setResult(result, isDate)
+		case cell.raw == "":
+			cell.resultType, cell.value = ExcelCellValueError, err.Error()
+		}
+		if err == nil || cell.raw == "" {
+			cell.valueType = ExcelCellValueFormula
+			return cell, nil
+		}
+	}
+
+	switch {
+	case cell.raw == "":
+		cell.resultType = ExcelCellValueEmpty
+	case cellType == excelize.CellTypeBool:
+		cell.resultType, cell.value = ExcelCellValueBool, cell.raw == "1" || strings.EqualFold(cell.raw, "TRUE")
+	case cellType == excelize.CellTypeError:
+		cell.resultType, cell.value = ExcelCellValueError, cell.raw
+	case isExcelStringCellType(cellType):
+		cell.resultType, cell.value = ExcelCellValueString, cell.raw
+	case cellType == excelize.CellTypeDate:
+		if t, err := time.ParseInLocation("2006-01-02T15:04:05", strings.TrimSuffix(cell.raw, "Z"), time.Local); err == nil {
+			cell.resultType, cell.value = ExcelCellValueDate, t
+		} else if t, err := ParseExcelDate(cell.raw); err == nil {
+			cell.resultType, cell.value = ExcelCellValueDate, t
+		} else {
+			cell.resultType, cell.value = ExcelCellValueString, cell.raw
+		}
+	default:
+		cell.setNumber(cell.raw, isDate)
+	}
+
+	cell.valueType = cell.resultType
+	if cell.formula != "" {
+		cell.valueType = ExcelCellValueFormula
+	}
+
+	return cell, nil
+}
+
+// isExcelStringCellType 是否为文本单元格（共享字符串、内联字符串及公式的文本结果）
+func isExcelStringCellType(cellType excelize.CellType) bool {
+	return cellType == excelize.CellTypeSharedString || cellType == excelize.CellTypeInlineString || cellType == excelize.CellTypeFormula
+}
+
+// numberFormat 根据样式编号获取数字格式及是否为日期格式
+func (r *ExcelReader) numberFormat(styleID int) (string, bool) {
+	styles := r.excel.Styles
+	if styles == nil || styles.CellXfs == nil || styleID < 0 || styleID >= len(styles.CellXfs.Xf) {
+		return "", false
+	}
+
+	numFmtID := 0
+	if styles.CellXfs.Xf[styleID].NumFmtID != nil {
+		numFmtID = *styles.CellXfs.Xf[styleID].NumFmtID
+	}
+	if styles.NumFmts != nil {
+		for _, numFmt := range styles.NumFmts.NumFmt {
+			if numFmt.NumFmtID == numFmtID {
+				return numFmt.FormatCode, isExcelDateFormat(numFmt.FormatCode)
+			}
+		}
+	}
+
+	// 27至36、50至58为中文区域设置的内置日期格式
+	if numFmtID >= 27 && numFmtID <= 36 || numFmtID >= 50 && numFmtID <= 58 {
+		return "", true
+	}
+	numberFormat := excelBuiltInNumberFormats[numFmtID]
+	return numberFormat, isExcelDateFormat(numberFormat)
+}
+
+// isExcelDateFormat 是否为日期时间格式（忽略引号内文本、方括号内的颜色及条件、转义字符，含[h]、[m]、[s]的经过时间格式按数字处理）
+func isExcelDateFormat(numberFormat string) bool {
+	var (
+		inQuote   bool
+		inBracket bool
+		escaped   bool
+		bracket   strings.Builder
+		isDate    bool
+	)
+	for _, char := range numberFormat {
+		switch {
+		case escaped:
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char == '"':
+			inQuote = !inQuote
+		case inQuote:
+		case char == '[':
+			inBracket = true
+			bracket.Reset()
+		case char == ']':
+			inBracket = false
+			if isExcelElapsedToken(bracket.String()) {
+				return false
+			}
+		case inBracket:
+			bracket.WriteRune(char)
+		case char == ';':
+			return isDate
+		case strings.ContainsRune("yYmMdDhHsS", char):
+			isDate = true
+		}
+	}
+	return isDate
+}
+
+// isExcelElapsedToken 是否为经过时间标记（如：h、hh、mm、ss，即[h]:mm:ss中方括号内的部分）
+func isExcelElapsedToken(token string) bool {
+	token = strings.ToLower(token)
+	if token == "" {
+		return false
+	}
+	for _, char := range token {
+		if char != rune(token[0]) {
+			return false
+		}
+	}
+	return strings.ContainsRune("hms", rune(token[0]))
+}
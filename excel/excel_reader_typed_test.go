@@ -0,0 +1,50 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestGetCellValueFormattedFormula(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "formula.xlsx")
+
+	f := excelize.NewFile()
+	numberFormat := "#,##0.00"
+	style, err := f.NewStyle(&excelize.Style{CustomNumFmt: &numberFormat})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for cell, value := range map[string]any{"A1": "金额", "A2": 1000.25, "A3": 1234.25} {
+		if err = f.SetCellValue("Sheet1", cell, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = f.SetCellFormula("Sheet1", "A4", "SUM(A2:A3)"); err != nil {
+		t.Fatal(err)
+	}
+	if err = f.SetCellStyle("Sheet1", "A2", "A4", style); err != nil {
+		t.Fatal(err)
+	}
+	if err = f.SaveAs(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, calcFormula := range []bool{false, true} {
+		reader := NewExcelReader().SetCollectErr(true).SetCalcFormula(calcFormula).OpenFile(filename).SetSheetName("Sheet1")
+		cell, err := reader.GetCellValue("A4")
+		if err != nil {
+			t.Fatalf("calcFormula=%v: %v", calcFormula, err)
+		}
+		if cell.GetType() != ExcelCellValueFormula || cell.GetResultType() != ExcelCellValueNumber {
+			t.Errorf("calcFormula=%v type: got %s/%s, want %s/%s", calcFormula, cell.GetType(), cell.GetResultType(), ExcelCellValueFormula, ExcelCellValueNumber)
+		}
+		if number, ok := cell.GetFloat64(); !ok || number != 2234.5 {
+			t.Errorf("calcFormula=%v value: got %v", calcFormula, cell.GetValue())
+		}
+		if cell.GetRaw() != "2234.5" {
+			t.Errorf("calcFormula=%v raw: got %q, want %q", calcFormula, cell.GetRaw(), "2234.5")
+		}
+	}
+}
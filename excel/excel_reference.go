@@ -0,0 +1,519 @@
+package excel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type (
+	// ExcelCellRef 单元格引用（列号、行号从1开始）
+	ExcelCellRef struct {
+		Sheet       string
+		Col         int
+		Row         int
+		ColAbsolute bool
+		RowAbsolute bool
+	}
+
+	// ExcelRangeRef 区域引用（Start为左上角，End为右下角）
+	ExcelRangeRef struct {
+		Sheet string
+		Start ExcelCellRef
+		End   ExcelCellRef
+	}
+)
+
+const (
+	// ExcelMaxColumns 最大列数（XFD）
+	ExcelMaxColumns = 16384
+	// ExcelMaxRows 最大行数
+	ExcelMaxRows = excelize.TotalRows
+)
+
+// NewExcelCellRef 构造函数（相对引用）
+func NewExcelCellRef(col, row int) ExcelCellRef {
+	return ExcelCellRef{Col: col, Row: row}
+}
+
+// ParseExcelCellRef 解析A1格式的单元格引用（支持小写、绝对引用标记及工作表名称，如：b2、$A$1、'Sheet 1'!A1）
+func ParseExcelCellRef(ref string) (ExcelCellRef, error) {
+	sheet, address, err := splitSheetReference(ref)
+	if err != nil {
+		return ExcelCellRef{}, err
+	}
+
+	cell, err := parseA1(address)
+	if err != nil {
+		return ExcelCellRef{}, fmt.Errorf("%w：%s", err, ref)
+	}
+	cell.Sheet = sheet
+
+	return cell, cell.Validate()
+}
+
+// ParseExcelR1C1 解析R1C1格式的单元格引用（R2C3为绝对引用，R[1]C[-1]为相对于base的引用，R、C后为空时表示与base同行、同列）
+func ParseExcelR1C1(ref string, base ExcelCellRef) (ExcelCellRef, error) {
+	sheet, address, err := splitSheetReference(ref)
+	if err != nil {
+		return ExcelCellRef{}, err
+	}
+
+	upper := strings.ToUpper(address)
+	cIndex := strings.IndexByte(upper, 'C')
+	if !strings.HasPrefix(upper, "R") || cIndex == -1 {
+		return ExcelCellRef{}, fmt.Errorf("%w：%s", ErrReferenceInvalid, ref)
+	}
+
+	row, rowAbsolute, err := parseR1C1Part(upper[1:cIndex], base.Row)
+	if err != nil {
+		return ExcelCellRef{}, fmt.Errorf("%w：%s", err, ref)
+	}
+	col, colAbsolute, err := parseR1C1Part(upper[cIndex+1:], base.Col)
+	if err != nil {
+		return ExcelCellRef{}, fmt.Errorf("%w：%s", err, ref)
+	}
+
+	cell := ExcelCellRef{Sheet: sheet, Col: col, Row: row, ColAbsolute: colAbsolute, RowAbsolute: rowAbsolute}
+	return cell, cell.Validate()
+}
+
+// Validate 校验行号、列号是否在有效范围内
+func (r ExcelCellRef) Validate() error {
+	if r.Col < 1 || r.Col > ExcelMaxColumns {
+		return fmt.Errorf("%w：列号%d", ErrReferenceOutOfRange, r.Col)
+	}
+	if r.Row < 1 || r.Row > ExcelMaxRows {
+		return fmt.Errorf("%w：行号%d", ErrReferenceOutOfRange, r.Row)
+	}
+	return nil
+}
+
+// Coordinate 获取坐标（不含工作表名称及绝对引用标记，如：B2），可直接用于excelize
+func (r ExcelCellRef) Coordinate() string {
+	column, _ := excelize.ColumnNumberToName(r.Col)
+	return column + strconv.Itoa(r.Row)
+}
+
+// String 格式化为A1格式（含工作表名称及绝对引用标记，如：'Sheet 1'!$B$2）
+func (r ExcelCellRef) String() string {
+	column, _ := excelize.ColumnNumberToName(r.Col)
+	var builder strings.Builder
+	if r.Sheet != "" {
+		builder.WriteString(QuoteSheetName(r.Sheet) + "!")
+	}
+	if r.ColAbsolute {
+		builder.WriteByte('$')
+	}
+	builder.WriteString(column)
+	if r.RowAbsolute {
+		builder.WriteByte('$')
+	}
+	builder.WriteString(strconv.Itoa(r.Row))
+	return builder.String()
+}
+
+// R1C1 格式化为R1C1格式（相对引用部分以base为基准，如：R2C[-1]）
+func (r ExcelCellRef) R1C1(base ExcelCellRef) string {
+	var builder strings.Builder
+	if r.Sheet != "" {
+		builder.WriteString(QuoteSheetName(r.Sheet) + "!")
+	}
+	builder.WriteString("R" + formatR1C1Part(r.Row, base.Row, r.RowAbsolute))
+	builder.WriteString("C" + formatR1C1Part(r.Col, base.Col, r.ColAbsolute))
+	return builder.String()
+}
+
+// Absolute 转为绝对引用（$A$1）
+func (r ExcelCellRef) Absolute() ExcelCellRef {
+	r.ColAbsolute, r.RowAbsolute = true, true
+	return r
+}
+
+// Relative 转为相对引用（A1）
+func (r ExcelCellRef) Relative() ExcelCellRef {
+	r.ColAbsolute, r.RowAbsolute = false, false
+	return r
+}
+
+// WithSheet 设置工作表名称
+func (r ExcelCellRef) WithSheet(sheet string) ExcelCellRef {
+	r.Sheet = sheet
+	return r
+}
+
+// Offset 偏移（rows、cols可为负数），超出范围时返回错误
+func (r ExcelCellRef) Offset(rows, cols int) (ExcelCellRef, error) {
+	r.Row, r.Col = r.Row+rows, r.Col+cols
+	return r, r.Validate()
+}
+
+// Equal 是否为同一单元格（忽略绝对引用标记）
+func (r ExcelCellRef) Equal(other ExcelCellRef) bool {
+	return r.Sheet == other.Sheet && r.Col == other.Col && r.Row == other.Row
+}
+
+// NewExcelRangeRef 构造函数（自动调整为左上角至右下角）
+func NewExcelRangeRef(start, end ExcelCellRef) ExcelRangeRef {
+	if start.Col > end.Col {
+		start.Col, end.Col = end.Col, start.Col
+		start.ColAbsolute, end.ColAbsolute = end.ColAbsolute, start.ColAbsolute
+	}
+	if start.Row > end.Row {
+		start.Row, end.Row = end.Row, start.Row
+		start.RowAbsolute, end.RowAbsolute = end.RowAbsolute, start.RowAbsolute
+	}
+	sheet := start.Sheet
+	if sheet == "" {
+		sheet = end.Sheet
+	}
+	start.Sheet, end.Sheet = "", ""
+
+	return ExcelRangeRef{Sheet: sheet, Start: start, End: end}
+}
+
+// ParseExcelRangeRef 解析区域引用（如：A1:C10、'Sheet 1'!$A$1:$C$10、A:C整列、1:3整行，单个单元格视为一个单元格的区域）
+func ParseExcelRangeRef(ref string) (ExcelRangeRef, error) {
+	sheet, address, err := splitSheetReference(ref)
+	if err != nil {
+		return ExcelRangeRef{}, err
+	}
+
+	parts := strings.Split(address, ":")
+	if len(parts) > 2 {
+		return ExcelRangeRef{}, fmt.Errorf("%w：%s", ErrReferenceInvalid, ref)
+	}
+	if len(parts) == 1 {
+		parts = append(parts, parts[0])
+	}
+
+	var cells [2]ExcelCellRef
+	for idx, part := range parts {
+		if cells[idx], err = parseA1(part); err != nil {
+			return ExcelRangeRef{}, fmt.Errorf("%w：%s", err, ref)
+		}
+	}
+	// 整列（A:C）或整行（1:3）
+	switch {
+	case cells[0].Row == 0 && cells[1].Row == 0 && cells[0].Col > 0 && cells[1].Col > 0:
+		cells[0].Row, cells[1].Row = 1, ExcelMaxRows
+	case cells[0].Col == 0 && cells[1].Col == 0 && cells[0].Row > 0 && cells[1].Row > 0:
+		cells[0].Col, cells[1].Col = 1, ExcelMaxColumns
+	}
+
+	rangeRef := NewExcelRangeRef(cells[0], cells[1])
+	rangeRef.Sheet = sheet
+
+	return rangeRef, rangeRef.Validate()
+}
+
+// Validate 校验区域是否在有效范围内
+func (r ExcelRangeRef) Validate() error {
+	if err := r.Start.Validate(); err != nil {
+		return err
+	}
+	return r.End.Validate()
+}
+
+// String 格式化为A1格式（如：'Sheet 1'!$A$1:$C$10，单个单元格时为A1）
+func (r ExcelRangeRef) String() string {
+	address := r.Start.String()
+	if !r.Start.Equal(r.End) || r.Start.ColAbsolute != r.End.ColAbsolute || r.Start.RowAbsolute != r.End.RowAbsolute {
+		address += ":" + r.End.String()
+	}
+	if r.Sheet != "" {
+		return QuoteSheetName(r.Sheet) + "!" + address
+	}
+	return address
+}
+
+// Coordinates 获取左上角及右下角坐标（如：A1、C10），可直接用于excelize
+func (r ExcelRangeRef) Coordinates() (string, string) {
+	return r.Start.Coordinate(), r.End.Coordinate()
+}
+
+// Rows 获取行数
+func (r ExcelRangeRef) Rows() int {
+	return r.End.Row - r.Start.Row + 1
+}
+
+// Cols 获取列数
+func (r ExcelRangeRef) Cols() int {
+	return r.End.Col - r.Start.Col + 1
+}
+
+// Absolute 转为绝对引用
+func (r ExcelRangeRef) Absolute() ExcelRangeRef {
+	r.Start, r.End = r.Start.Absolute(), r.End.Absolute()
+	return r
+}
+
+// WithSheet 设置工作表名称
+func (r ExcelRangeRef) WithSheet(sheet string) ExcelRangeRef {
+	r.Sheet = sheet
+	return r
+}
+
+// Contains 是否包含单元格（单元格未指定工作表时忽略工作表名称）
+func (r ExcelRangeRef) Contains(cell ExcelCellRef) bool {
+	if cell.Sheet != "" && r.Sheet != "" && cell.Sheet != r.Sheet {
+		return false
+	}
+	return cell.Col >= r.Start.Col && cell.Col <= r.End.Col && cell.Row >= r.Start.Row && cell.Row <= r.End.Row
+}
+
+// Each 按行遍历区域内的单元格（fn返回false时停止）
+func (r ExcelRangeRef) Each(fn func(cell ExcelCellRef) bool) {
+	for row := r.Start.Row; row <= r.End.Row; row++ {
+		for col := r.Start.Col; col <= r.End.Col; col++ {
+			if !fn(ExcelCellRef{Sheet: r.Sheet, Col: col, Row: row}) {
+				return
+			}
+		}
+	}
+}
+
+// Cells 获取区域内的全部单元格（按行排列）
+func (r ExcelRangeRef) Cells() []ExcelCellRef {
+	cells := make([]ExcelCellRef, 0, r.Rows()*r.Cols())
+	r.Each(func(cell ExcelCellRef) bool {
+		cells = append(cells, cell)
+		return true
+	})
+	return cells
+}
+
+// Intersect 交集（没有交集时返回false）
+func (r ExcelRangeRef) Intersect(other ExcelRangeRef) (ExcelRangeRef, bool) {
+	if r.Sheet != "" && other.Sheet != "" && r.Sheet != other.Sheet {
+		return ExcelRangeRef{}, false
+	}
+
+	start := NewExcelCellRef(maxInt(r.Start.Col, other.Start.Col), maxInt(r.Start.Row, other.Start.Row))
+	end := NewExcelCellRef(minInt(r.End.Col, other.End.Col), minInt(r.End.Row, other.End.Row))
+	if start.Col > end.Col || start.Row > end.Row {
+		return ExcelRangeRef{}, false
+	}
+
+	return ExcelRangeRef{Sheet: r.Sheet, Start: start, End: end}, true
+}
+
+// Union 并集（同时包含两个区域的最小区域）
+func (r ExcelRangeRef) Union(other ExcelRangeRef) ExcelRangeRef {
+	sheet := r.Sheet
+	if sheet == "" {
+		sheet = other.Sheet
+	}
+	start := NewExcelCellRef(minInt(r.Start.Col, other.Start.Col), minInt(r.Start.Row, other.Start.Row))
+	end := NewExcelCellRef(maxInt(r.End.Col, other.End.Col), maxInt(r.End.Row, other.End.Row))
+
+	return ExcelRangeRef{Sheet: sheet, Start: start, End: end}
+}
+
+// Offset 整体偏移（rows、cols可为负数），超出范围时返回错误
+func (r ExcelRangeRef) Offset(rows, cols int) (ExcelRangeRef, error) {
+	var err error
+	if r.Start, err = r.Start.Offset(rows, cols); err != nil {
+		return r, err
+	}
+	if r.End, err = r.End.Offset(rows, cols); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// Resize 调整大小（保持左上角不变）
+func (r ExcelRangeRef) Resize(rows, cols int) (ExcelRangeRef, error) {
+	if rows < 1 || cols < 1 {
+		return r, fmt.Errorf("%w：%d行%d列", ErrReferenceOutOfRange, rows, cols)
+	}
+	r.End.Row, r.End.Col = r.Start.Row+rows-1, r.Start.Col+cols-1
+	return r, r.End.Validate()
+}
+
+// QuoteSheetName 工作表名称加引号（含空格、符号、以数字开头或与A1、R1C1格式的地址相同时，单引号转义为两个单引号）
+func QuoteSheetName(sheet string) string {
+	if _, err := parseA1(sheet); err == nil || isR1C1Name(sheet) {
+		return "'" + sheet + "'"
+	}
+	for idx, char := range sheet {
+		if !(char == '_' || unicode.IsLetter(char) || unicode.IsDigit(char) && idx > 0) {
+			return "'" + strings.ReplaceAll(sheet, "'", "''") + "'"
+		}
+	}
+	return sheet
+}
+
+// splitSheetReference 拆分工作表名称及地址
+func splitSheetReference(ref string) (string, string, error) {
+	ref = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(ref), "="))
+	if ref == "" {
+		return "", "", ErrReferenceInvalid
+	}
+
+	if strings.HasPrefix(ref, "'") {
+		for idx := 1; idx < len(ref); idx++ {
+			if ref[idx] != '\'' {
+				continue
+			}
+			if idx+1 < len(ref) && ref[idx+1] == '\'' {
+				idx++
+				continue
+			}
+			if idx+1 >= len(ref) || ref[idx+1] != '!' || idx == 1 {
+				return "", "", fmt.Errorf("%w：%s", ErrReferenceInvalid, ref)
+			}
+			return strings.ReplaceAll(ref[1:idx], "''", "'"), ref[idx+2:], nil
+		}
+		return "", "", fmt.Errorf("%w：%s", ErrReferenceInvalid, ref)
+	}
+
+	if idx := strings.LastIndexByte(ref, '!'); idx != -1 {
+		if idx == 0 {
+			return "", "", fmt.Errorf("%w：%s", ErrReferenceInvalid, ref)
+		}
+		return ref[:idx], ref[idx+1:], nil
+	}
+
+	return "", ref, nil
+}
+
+// parseA1 解析A1格式地址（允许只有列或只有行，缺少的部分为0）
+func parseA1(address string) (ExcelCellRef, error) {
+	var (
+		cell ExcelCellRef
+		idx  int
+	)
+
+	if idx < len(address) && address[idx] == '$' {
+		cell.ColAbsolute = true
+		idx++
+	}
+	colStart := idx
+	for idx < len(address) && isASCIILetter(address[idx]) {
+		idx++
+	}
+	if idx > colStart {
+		col, err := parseColumn(address[colStart:idx])
+		if err != nil {
+			return cell, err
+		}
+		cell.Col = col
+	} else if cell.ColAbsolute {
+		// 只有行时$属于行
+		cell.ColAbsolute, cell.RowAbsolute = false, true
+	}
+
+	if idx < len(address) && address[idx] == '$' && !cell.RowAbsolute {
+		cell.RowAbsolute = true
+		idx++
+	}
+	rowStart := idx
+	for idx < len(address) && address[idx] >= '0' && address[idx] <= '9' {
+		idx++
+	}
+	if idx != len(address) || (cell.Col == 0 && idx == rowStart) {
+		return cell, ErrReferenceInvalid
+	}
+	if idx > rowStart {
+		row, err := strconv.Atoi(address[rowStart:idx])
+		if err != nil || row == 0 {
+			return cell, ErrReferenceInvalid
+		}
+		cell.Row = row
+	} else if cell.RowAbsolute {
+		return cell, ErrReferenceInvalid
+	}
+
+	return cell, nil
+}
+
+// isR1C1Name 是否与R1C1格式的地址相同（如：R、C、RC、R1C1、R2C、C3，不区分大小写）
+func isR1C1Name(name string) bool {
+	var (
+		upper = strings.ToUpper(name)
+		idx   int
+	)
+	for _, prefix := range []byte{'R', 'C'} {
+		if idx < len(upper) && upper[idx] == prefix {
+			idx++
+			for idx < len(upper) && upper[idx] >= '0' && upper[idx] <= '9' {
+				idx++
+			}
+		}
+	}
+	return idx > 0 && idx == len(upper)
+}
+
+// parseColumn 列文字转列号（不区分大小写）
+func parseColumn(column string) (int, error) {
+	if column == "" || len(column) > 3 {
+		return 0, ErrReferenceInvalid
+	}
+	result := 0
+	for idx := 0; idx < len(column); idx++ {
+		if !isASCIILetter(column[idx]) {
+			return 0, ErrReferenceInvalid
+		}
+		result = result*26 + int((column[idx]|0x20)-'a') + 1
+	}
+	if result > ExcelMaxColumns {
+		return 0, ErrReferenceOutOfRange
+	}
+	return result, nil
+}
+
+// parseR1C1Part 解析R1C1中行或列的部分（数字为绝对引用，[n]为相对偏移，空为偏移0）
+func parseR1C1Part(part string, base int) (int, bool, error) {
+	if part == "" {
+		return base, false, nil
+	}
+	if strings.HasPrefix(part, "[") && strings.HasSuffix(part, "]") {
+		offset, err := strconv.Atoi(part[1 : len(part)-1])
+		if err != nil {
+			return 0, false, ErrReferenceInvalid
+		}
+		return base + offset, false, nil
+	}
+	number, err := strconv.Atoi(part)
+	if err != nil || number < 1 {
+		return 0, false, ErrReferenceInvalid
+	}
+	return number, true, nil
+}
+
+// formatR1C1Part 格式化R1C1中行或列的部分
+func formatR1C1Part(number, base int, absolute bool) string {
+	switch {
+	case absolute:
+		return strconv.Itoa(number)
+	case number == base:
+		return ""
+	default:
+		return "[" + strconv.Itoa(number-base) + "]"
+	}
+}
+
+// isASCIILetter 是否为英文字母
+func isASCIILetter(char byte) bool {
+	return char >= 'A' && char <= 'Z' || char >= 'a' && char <= 'z'
+}
+
+// minInt 较小值
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// maxInt 较大值
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
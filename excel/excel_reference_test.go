@@ -0,0 +1,193 @@
+package excel
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseExcelCellRef(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want ExcelCellRef
+		err  error
+	}{
+		{ref: "B2", want: ExcelCellRef{Col: 2, Row: 2}},
+		{ref: "b2", want: ExcelCellRef{Col: 2, Row: 2}},
+		{ref: "$A$1", want: ExcelCellRef{Col: 1, Row: 1, ColAbsolute: true, RowAbsolute: true}},
+		{ref: "$C5", want: ExcelCellRef{Col: 3, Row: 5, ColAbsolute: true}},
+		{ref: "C$5", want: ExcelCellRef{Col: 3, Row: 5, RowAbsolute: true}},
+		{ref: "'Sheet 1'!$XFD$1048576", want: ExcelCellRef{Sheet: "Sheet 1", Col: ExcelMaxColumns, Row: ExcelMaxRows, ColAbsolute: true, RowAbsolute: true}},
+		{ref: "'It''s'!A1", want: ExcelCellRef{Sheet: "It's", Col: 1, Row: 1}},
+		{ref: "A0", err: ErrReferenceInvalid},
+		{ref: "A$", err: ErrReferenceInvalid},
+		{ref: "$$A1", err: ErrReferenceInvalid},
+		{ref: "A1B", err: ErrReferenceInvalid},
+		{ref: "XFE1", err: ErrReferenceOutOfRange},
+		{ref: "A1048577", err: ErrReferenceOutOfRange},
+	}
+
+	for _, test := range tests {
+		got, err := ParseExcelCellRef(test.ref)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("ParseExcelCellRef(%q) error: got %v, want %v", test.ref, err, test.err)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("ParseExcelCellRef(%q): got %+v (%v), want %+v", test.ref, got, err, test.want)
+		}
+	}
+}
+
+func TestParseExcelRangeRef(t *testing.T) {
+	tests := []struct {
+		ref        string
+		start, end ExcelCellRef
+		err        error
+	}{
+		{ref: "A1:C10", start: ExcelCellRef{Col: 1, Row: 1}, end: ExcelCellRef{Col: 3, Row: 10}},
+		{ref: "C10:A1", start: ExcelCellRef{Col: 1, Row: 1}, end: ExcelCellRef{Col: 3, Row: 10}},
+		{ref: "B2", start: ExcelCellRef{Col: 2, Row: 2}, end: ExcelCellRef{Col: 2, Row: 2}},
+		{ref: "$A$1:$B$2", start: ExcelCellRef{Col: 1, Row: 1, ColAbsolute: true, RowAbsolute: true}, end: ExcelCellRef{Col: 2, Row: 2, ColAbsolute: true, RowAbsolute: true}},
+		{ref: "A:C", start: ExcelCellRef{Col: 1, Row: 1}, end: ExcelCellRef{Col: 3, Row: ExcelMaxRows}},
+		{ref: "$A:$C", start: ExcelCellRef{Col: 1, Row: 1, ColAbsolute: true}, end: ExcelCellRef{Col: 3, Row: ExcelMaxRows, ColAbsolute: true}},
+		{ref: "1:3", start: ExcelCellRef{Col: 1, Row: 1}, end: ExcelCellRef{Col: ExcelMaxColumns, Row: 3}},
+		{ref: "$2:$4", start: ExcelCellRef{Col: 1, Row: 2, RowAbsolute: true}, end: ExcelCellRef{Col: ExcelMaxColumns, Row: 4, RowAbsolute: true}},
+		{ref: "A:3", err: ErrReferenceOutOfRange},
+		{ref: "A1:B2:C3", err: ErrReferenceInvalid},
+	}
+
+	for _, test := range tests {
+		got, err := ParseExcelRangeRef(test.ref)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("ParseExcelRangeRef(%q) error: got %v, want %v", test.ref, err, test.err)
+			}
+			continue
+		}
+		if err != nil || got.Start != test.start || got.End != test.end {
+			t.Errorf("ParseExcelRangeRef(%q): got %+v-%+v (%v), want %+v-%+v", test.ref, got.Start, got.End, err, test.start, test.end)
+		}
+	}
+}
+
+func TestParseExcelR1C1(t *testing.T) {
+	base := NewExcelCellRef(3, 5)
+	tests := []struct {
+		ref  string
+		want ExcelCellRef
+		err  error
+	}{
+		{ref: "R2C3", want: ExcelCellRef{Col: 3, Row: 2, ColAbsolute: true, RowAbsolute: true}},
+		{ref: "RC", want: ExcelCellRef{Col: 3, Row: 5}},
+		{ref: "R[1]C[-1]", want: ExcelCellRef{Col: 2, Row: 6}},
+		{ref: "r[-4]c1", want: ExcelCellRef{Col: 1, Row: 1, ColAbsolute: true}},
+		{ref: "R1C[2]", want: ExcelCellRef{Col: 5, Row: 1, RowAbsolute: true}},
+		{ref: "R[-5]C", err: ErrReferenceOutOfRange},
+		{ref: "R0C1", err: ErrReferenceInvalid},
+		{ref: "R1", err: ErrReferenceInvalid},
+	}
+
+	for _, test := range tests {
+		got, err := ParseExcelR1C1(test.ref, base)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("ParseExcelR1C1(%q) error: got %v, want %v", test.ref, err, test.err)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("ParseExcelR1C1(%q): got %+v (%v), want %+v", test.ref, got, err, test.want)
+		}
+		if r1c1 := got.R1C1(base); r1c1 != strings.ToUpper(test.ref) {
+			t.Errorf("R1C1(%q): got %q", test.ref, r1c1)
+		}
+	}
+}
+
+func TestExcelRangeRefIntersectUnion(t *testing.T) {
+	tests := []struct {
+		a, b      string
+		intersect string
+		union     string
+	}{
+		{a: "A1:C3", b: "B2:D4", intersect: "B2:C3", union: "A1:D4"},
+		{a: "A1:B2", b: "C3:D4", intersect: "", union: "A1:D4"},
+		{a: "A1:D4", b: "B2", intersect: "B2", union: "A1:D4"},
+		{a: "Sheet1!A1:C3", b: "Sheet2!A1:C3", intersect: "", union: "Sheet1!A1:C3"},
+		{a: "Sheet1!A1:C3", b: "B2:E5", intersect: "Sheet1!B2:C3", union: "Sheet1!A1:E5"},
+	}
+
+	for _, test := range tests {
+		a, err := ParseExcelRangeRef(test.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ParseExcelRangeRef(test.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		intersect, ok := a.Intersect(b)
+		if got := map[bool]string{true: intersect.String()}[ok]; got != test.intersect {
+			t.Errorf("%s ∩ %s: got %q, want %q", test.a, test.b, got, test.intersect)
+		}
+		if got := a.Union(b).String(); got != test.union {
+			t.Errorf("%s ∪ %s: got %q, want %q", test.a, test.b, got, test.union)
+		}
+	}
+}
+
+func TestColumnTextToNumber(t *testing.T) {
+	tests := map[string]int{
+		"A":     1,
+		"z":     26,
+		"AA":    27,
+		" $AB ": 28,
+		"XFD":   ExcelMaxColumns,
+		"XFE":   0,
+		"AAAA":  0,
+		"A1":    0,
+		"":      0,
+	}
+
+	for text, want := range tests {
+		if got := ColumnTextToNumber(text); got != want {
+			t.Errorf("ColumnTextToNumber(%q): got %d, want %d", text, got, want)
+		}
+	}
+}
+
+func TestQuoteSheetName(t *testing.T) {
+	tests := map[string]string{
+		"Sheet1":  "Sheet1",
+		"数据":      "数据",
+		"Rate":    "Rate",
+		"R1C1X":   "R1C1X",
+		"Sheet 1": "'Sheet 1'",
+		"It's":    "'It''s'",
+		"2024":    "'2024'",
+		"A1":      "'A1'",
+		"XFD100":  "'XFD100'",
+		"AB":      "'AB'",
+		"R":       "'R'",
+		"C":       "'C'",
+		"RC":      "'RC'",
+		"rc":      "'rc'",
+		"R1C1":    "'R1C1'",
+		"R2C":     "'R2C'",
+		"RC3":     "'RC3'",
+		"R10":     "'R10'",
+		"C3":      "'C3'",
+		"Sheet-1": "'Sheet-1'",
+		"_data":   "_data",
+	}
+
+	for sheet, want := range tests {
+		if got := QuoteSheetName(sheet); got != want {
+			t.Errorf("QuoteSheetName(%q): got %q, want %q", sheet, got, want)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ExcelRichTextRun 富文本片段（同一单元格内各片段可使用不同字体）
+type ExcelRichTextRun struct {
+	text          string
+	fontColor     string
+	fontBold      bool
+	fontItalic    bool
+	fontFamily    string
+	fontSize      float64
+	fontUnderline string
+	fontStrike    bool
+}
+
+// NewExcelRichTextRun 构造函数
+func NewExcelRichTextRun(text string) *ExcelRichTextRun {
+	return &ExcelRichTextRun{text: text}
+}
+
+// NewExcelCellRichText 构造函数（富文本格式）
+//
+//	NewExcelCellRichText(NewExcelRichTextRun("金额："), NewExcelRichTextRun("10,000").SetFontBold(true).SetFontColor("#FF0000"), NewExcelRichTextRun("元"))
+func NewExcelCellRichText(runs ...*ExcelRichTextRun) *ExcelCell {
+	return &ExcelCell{content: runs, contentType: ExcelCellContentTypeRichText}
+}
+
+// ParseExcelRichText 按**标记拆分富文本（标记内的片段使用emphasis的字体，如："金额：**10,000**元"）
+func ParseExcelRichText(text string, emphasis *ExcelRichTextRun) []*ExcelRichTextRun {
+	var runs []*ExcelRichTextRun
+	for idx, part := range strings.Split(text, "**") {
+		if part == "" {
+			continue
+		}
+		run := NewExcelRichTextRun(part)
+		if idx%2 == 1 && emphasis != nil {
+			copied := *emphasis
+			copied.text = part
+			run = &copied
+		}
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// GetText 获取文本
+func (r *ExcelRichTextRun) GetText() string {
+	return r.text
+}
+
+// GetFontColor 获取字体颜色
+func (r *ExcelRichTextRun) GetFontColor() string {
+	return r.fontColor
+}
+
+// SetFontColor 设置字体颜色
+func (r *ExcelRichTextRun) SetFontColor(fontColor string) *ExcelRichTextRun {
+	r.fontColor = fontColor
+	return r
+}
+
+// GetFontBold 获取字体粗体
+func (r *ExcelRichTextRun) GetFontBold() bool {
+	return r.fontBold
+}
+
+// SetFontBold 设置字体粗体
+func (r *ExcelRichTextRun) SetFontBold(fontBold bool) *ExcelRichTextRun {
+	r.fontBold = fontBold
+	return r
+}
+
+// GetFontItalic 获取字体斜体
+func (r *ExcelRichTextRun) GetFontItalic() bool {
+	return r.fontItalic
+}
+
+// SetFontItalic 设置字体斜体
+func (r *ExcelRichTextRun) SetFontItalic(fontItalic bool) *ExcelRichTextRun {
+	r.fontItalic = fontItalic
+	return r
+}
+
+// GetFontFamily 获取字体
+func (r *ExcelRichTextRun) GetFontFamily() string {
+	return r.fontFamily
+}
+
+// SetFontFamily 设置字体
+func (r *ExcelRichTextRun) SetFontFamily(fontFamily string) *ExcelRichTextRun {
+	r.fontFamily = fontFamily
+	return r
+}
+
+// GetFontSize 获取字体字号
+func (r *ExcelRichTextRun) GetFontSize() float64 {
+	return r.fontSize
+}
+
+// SetFontSize 设置字体字号
+func (r *ExcelRichTextRun) SetFontSize(fontSize float64) *ExcelRichTextRun {
+	r.fontSize = fontSize
+	return r
+}
+
+// GetFontUnderline 获取下划线
+func (r *ExcelRichTextRun) GetFontUnderline() string {
+	return r.fontUnderline
+}
+
+// SetFontUnderline 设置下划线（single、double）
+func (r *ExcelRichTextRun) SetFontUnderline(fontUnderline string) *ExcelRichTextRun {
+	r.fontUnderline = fontUnderline
+	return r
+}
+
+// GetFontStrike 获取删除线
+func (r *ExcelRichTextRun) GetFontStrike() bool {
+	return r.fontStrike
+}
+
+// SetFontStrike 设置删除线
+func (r *ExcelRichTextRun) SetFontStrike(fontStrike bool) *ExcelRichTextRun {
+	r.fontStrike = fontStrike
+	return r
+}
+
+// hasFont 是否设置了字体
+func (r *ExcelRichTextRun) hasFont() bool {
+	return r.fontColor != "" || r.fontBold || r.fontItalic || r.fontFamily != "" || r.fontSize > 0 || r.fontUnderline != "" || r.fontStrike
+}
+
+// newExcelizeRichText 转换为excelize富文本
+func newExcelizeRichText(runs []*ExcelRichTextRun) []excelize.RichTextRun {
+	richText := make([]excelize.RichTextRun, 0, len(runs))
+	for _, run := range runs {
+		if run == nil {
+			continue
+		}
+		richTextRun := excelize.RichTextRun{Text: run.text}
+		if run.hasFont() {
+			richTextRun.Font = &excelize.Font{
+				Bold:      run.fontBold,
+				Italic:    run.fontItalic,
+				Underline: run.fontUnderline,
+				Family:    run.fontFamily,
+				Size:      run.fontSize,
+				Strike:    run.fontStrike,
+				Color:     run.fontColor,
+			}
+		}
+		richText = append(richText, richTextRun)
+	}
+	return richText
+}
+
+// newExcelRichText 由excelize富文本转换（颜色统一为#RRGGBB格式）
+func newExcelRichText(richText []excelize.RichTextRun) []*ExcelRichTextRun {
+	runs := make([]*ExcelRichTextRun, 0, len(richText))
+	for _, richTextRun := range richText {
+		run := NewExcelRichTextRun(richTextRun.Text)
+		if font := richTextRun.Font; font != nil {
+			run.fontBold, run.fontItalic, run.fontStrike = font.Bold, font.Italic, font.Strike
+			run.fontFamily, run.fontSize = font.Family, font.Size
+			if font.Underline != "none" {
+				run.fontUnderline = font.Underline
+			}
+			if font.Color != "" {
+				run.fontColor = "#" + strings.TrimPrefix(font.Color, "#")
+			}
+		}
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// GetCellRichText 读取当前工作表单元格的富文本片段（如：B2，普通文本返回不带字体的单个片段，非文本单元格返回nil）
+func (r *ExcelReader) GetCellRichText(coordinate string) ([]*ExcelRichTextRun, error) {
+	if err := r.checkSheet(); err != nil {
+		return nil, err
+	}
+
+	cellType, err := r.excel.GetCellType(r.GetSheetName(), coordinate)
+	if err != nil {
+		return nil, NewCellErrorByCoordinate(coordinate, "", fmt.Errorf("读取富文本错误：%w", err))
+	}
+	if !isExcelStringCellType(cellType) {
+		return nil, nil
+	}
+
+	richText, err := r.excel.GetCellRichText(r.GetSheetName(), coordinate)
+	if err != nil {
+		return nil, NewCellErrorByCoordinate(coordinate, "", fmt.Errorf("读取富文本错误：%w", err))
+	}
+	if len(richText) > 0 {
+		return newExcelRichText(richText), nil
+	}
+
+	content, err := r.excel.GetCellValue(r.GetSheetName(), coordinate)
+	if err != nil {
+		return nil, NewCellErrorByCoordinate(coordinate, "", fmt.Errorf("读取富文本错误：%w", err))
+	}
+	if content == "" {
+		return nil, nil
+	}
+
+	return []*ExcelRichTextRun{NewExcelRichTextRun(content)}, nil
+}
+
+// GetRichText 根据数据行号（ToList的键）及表头读取富文本片段
+func (r *ExcelReader) GetRichText(rowNumber uint64, title string) ([]*ExcelRichTextRun, error) {
+	for idx, t := range r.GetTitle() {
+		if t == title {
+			coordinate, err := excelize.CoordinatesToCellName(idx+1, int(r.GetExcelRowNumber(rowNumber)))
+			if err != nil {
+				return nil, err
+			}
+			return r.GetCellRichText(coordinate)
+		}
+	}
+
+	return nil, fmt.Errorf("表头不存在：%s", title)
+}
@@ -1,16 +1,15 @@
 package excel
 
 import (
-	"errors"
 	"fmt"
-
-	"github.com/xuri/excelize/v2"
 )
 
 // ExcelRow Excel行
 type ExcelRow struct {
-	cells     []*ExcelCell
-	rowNumber uint64
+	cells      []*ExcelCell
+	rowNumber  uint64
+	collectErr bool
+	err        error
 }
 
 // NewExcelRow 构造函数
@@ -18,6 +17,28 @@ func NewExcelRow() *ExcelRow {
 	return &ExcelRow{}
 }
 
+// SetCollectErr 设置错误收集模式（开启后出错不再panic，通过Err获取错误）
+func (r *ExcelRow) SetCollectErr(collectErr bool) *ExcelRow {
+	r.collectErr = collectErr
+	return r
+}
+
+// Err 获取错误
+func (r *ExcelRow) Err() error {
+	return r.err
+}
+
+// fail 记录错误（未开启错误收集模式时panic）
+func (r *ExcelRow) fail(err error) *ExcelRow {
+	if r.err == nil {
+		r.err = err
+	}
+	if !r.collectErr {
+		panic(err)
+	}
+	return r
+}
+
 // GetCells 获取单元格组
 func (r *ExcelRow) GetCells() []*ExcelCell {
 	return r.cells
@@ -26,15 +47,15 @@ func (r *ExcelRow) GetCells() []*ExcelCell {
 // SetCells 设置单元格组
 func (r *ExcelRow) SetCells(cells []*ExcelCell) *ExcelRow {
 	if r.GetRowNumber() == 0 {
-		panic(errors.New("行标必须大于0"))
+		return r.fail(ErrRowNumberInvalid)
 	}
 
 	for colNumber, cell := range cells {
-		if colText, err := excelize.ColumnNumberToName(colNumber + 1); err != nil {
-			panic(fmt.Errorf("列索引转列文字失败：%d，%d", r.GetRowNumber(), colNumber+1))
-		} else {
-			cell.SetCoordinate(fmt.Sprintf("%s%d", colText, r.GetRowNumber()))
+		ref := NewExcelCellRef(colNumber+1, int(r.GetRowNumber()))
+		if err := ref.Validate(); err != nil {
+			return r.fail(fmt.Errorf("设置单元格坐标失败（第%d行第%d列）：%w", r.GetRowNumber(), colNumber+1, err))
 		}
+		cell.SetCoordinate(ref.Coordinate())
 	}
 	r.cells = cells
 
@@ -0,0 +1,303 @@
+package excel
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jericho-yu/outil/common"
+)
+
+type (
+	// excelTag 结构体标签：`excel:"标题,key=value"`，标题为"-"时忽略该字段
+	excelTag struct {
+		title   string
+		omit    bool
+		options map[string]string
+	}
+
+	// excelField 结构体字段元数据
+	excelField struct {
+		index []int
+		title string
+		typ   reflect.Type
+		tag   excelTag
+	}
+)
+
+var (
+	timeType                = reflect.TypeOf(time.Time{})
+	durationType            = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType     = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType       = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	boolTexts               = map[string]bool{"true": true, "false": false, "1": true, "0": false, "yes": true, "no": false, "on": true, "off": false, "y": true, "n": false, "t": true, "f": false, "是": true, "否": false}
+	errUnsupportedFieldType = errors.New("不支持的字段类型")
+)
+
+// parseExcelTag 解析结构体标签
+func parseExcelTag(tag string) excelTag {
+	var (
+		parts  = strings.Split(tag, ",")
+		result = excelTag{title: strings.TrimSpace(parts[0]), options: make(map[string]string)}
+	)
+
+	if result.title == "-" {
+		result.omit = true
+	}
+	// 不含"="的片段拼接回上一个选项值，以便支持"#,##0.00"之类带逗号的格式
+	lastKey := ""
+	for _, part := range parts[1:] {
+		if kv := strings.SplitN(part, "=", 2); len(kv) == 2 {
+			lastKey = strings.TrimSpace(kv[0])
+			result.options[lastKey] = strings.TrimSpace(kv[1])
+		} else if lastKey != "" {
+			result.options[lastKey] += "," + part
+		} else if key := strings.TrimSpace(part); key != "" {
+			result.options[key] = ""
+		}
+	}
+
+	return result
+}
+
+// isLeafType 是否为叶子类型（不再展开的结构体）
+func isLeafType(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || typ == timeType {
+		return true
+	}
+	return reflect.PointerTo(typ).Implements(textUnmarshalerType) || typ.Implements(textMarshalerType)
+}
+
+// structFields 获取结构体字段（嵌入及嵌套结构体展开，嵌套字段标题以"/"拼接）
+func structFields(typ reflect.Type) []excelField {
+	return appendStructFields(nil, typ, nil, "")
+}
+
+func appendStructFields(fields []excelField, typ reflect.Type, index []int, prefix string) []excelField {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		var (
+			field  = typ.Field(i)
+			tag    = parseExcelTag(field.Tag.Get("excel"))
+			_index = append(append([]int{}, index...), i)
+		)
+
+		if tag.omit || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+
+		if !isLeafType(field.Type) {
+			_prefix := prefix
+			if tag.title != "" {
+				_prefix = joinTitle(prefix, tag.title)
+			}
+			fields = appendStructFields(fields, field.Type, _index, _prefix)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		title := tag.title
+		if title == "" {
+			title = field.Name
+		}
+		fields = append(fields, excelField{index: _index, title: joinTitle(prefix, title), typ: field.Type, tag: tag})
+	}
+
+	return fields
+}
+
+// joinTitle 拼接嵌套标题
+func joinTitle(prefix, title string) string {
+	if prefix == "" {
+		return title
+	}
+	return prefix + "/" + title
+}
+
+// fieldByIndexAlloc 根据索引获取字段（遇到空指针时自动创建，未导出的嵌入指针无法创建，返回错误）
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 {
+			for v.Kind() == reflect.Pointer {
+				if v.IsNil() {
+					if !v.CanSet() {
+						return reflect.Value{}, fmt.Errorf("无法创建未导出的嵌入指针：%s", v.Type().String())
+					}
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+				v = v.Elem()
+			}
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+// fieldByIndexSafe 根据索引获取字段（遇到空指针时返回无效值）
+func fieldByIndexSafe(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 {
+			for v.Kind() == reflect.Pointer {
+				if v.IsNil() {
+					return reflect.Value{}
+				}
+				v = v.Elem()
+			}
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// setFieldValue 将单元格内容转换为字段类型并赋值
+func setFieldValue(v reflect.Value, content string, tag excelTag) error {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		elem := reflect.New(v.Type().Elem())
+		if err := setFieldValue(elem.Elem(), content, tag); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	switch v.Type() {
+	case timeType:
+		t, err := parseTime(content, tag.options["layout"])
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := ParseExcelDuration(content)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(content))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(content)
+	case reflect.Bool:
+		b, ok := boolTexts[strings.ToLower(content)]
+		if !ok {
+			return fmt.Errorf("无法转换为布尔：%s", content)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := parseInt(content)
+		if err != nil {
+			return err
+		}
+		if v.OverflowInt(n) {
+			return fmt.Errorf("整数超出范围：%s", content)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := parseUint(content)
+		if err != nil {
+			return err
+		}
+		if v.OverflowUint(n) {
+			return fmt.Errorf("整数超出范围：%s", content)
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		number := strings.ReplaceAll(content, ",", "")
+		if _, err := strconv.ParseFloat(number, 64); err != nil {
+			return fmt.Errorf("无法转换为小数：%s", content)
+		}
+		f := common.ToFloat64(number)
+		if v.OverflowFloat(f) {
+			return fmt.Errorf("小数超出范围：%s", content)
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("%w：%s", errUnsupportedFieldType, v.Type().String())
+	}
+
+	return nil
+}
+
+// parseInt 解析整数（忽略千分位逗号，允许12.0这类小数部分为0的写法）
+func parseInt(content string) (int64, error) {
+	number := strings.ReplaceAll(content, ",", "")
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err == nil {
+		return n, nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, fmt.Errorf("整数超出范围：%s", content)
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil || f != math.Trunc(f) {
+		return 0, fmt.Errorf("无法转换为整数：%s", content)
+	}
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("整数超出范围：%s", content)
+	}
+
+	return int64(f), nil
+}
+
+// parseUint 解析非负整数（忽略千分位逗号，允许12.0这类小数部分为0的写法）
+func parseUint(content string) (uint64, error) {
+	number := strings.ReplaceAll(content, ",", "")
+	n, err := strconv.ParseUint(number, 10, 64)
+	if err == nil {
+		return n, nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, fmt.Errorf("整数超出范围：%s", content)
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil || f != math.Trunc(f) || f < 0 {
+		return 0, fmt.Errorf("无法转换为非负整数：%s", content)
+	}
+	if f >= math.MaxUint64 {
+		return 0, fmt.Errorf("整数超出范围：%s", content)
+	}
+
+	return uint64(f), nil
+}
+
+// parseTime 解析时间（未指定格式时使用ParseExcelDate）
+func parseTime(content, layout string) (time.Time, error) {
+	if layout == "" {
+		return ParseExcelDate(content)
+	}
+
+	t, err := time.ParseInLocation(layout, content, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("无法按格式%s转换为时间：%s", layout, content)
+	}
+
+	return t, nil
+}
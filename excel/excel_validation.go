@@ -0,0 +1,326 @@
+package excel
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type (
+	// ExcelRuleCheck 自定义校验函数（可通过row访问同一行其他列，用于跨字段校验）
+	ExcelRuleCheck func(content string, row map[string]string) error
+
+	// ExcelRule 列校验规则
+	ExcelRule struct {
+		title    string
+		required bool
+		min      *float64
+		max      *float64
+		pattern  *regexp.Regexp
+		enums    []string
+		unique   bool
+		checks   []ExcelRuleCheck
+		err      error
+	}
+
+	// ExcelValidationReport 校验报告
+	ExcelValidationReport struct {
+		reader        *ExcelReader
+		missingTitles []string
+		errors        []*CellError
+	}
+)
+
+// NewExcelRule 构造函数（根据表头标题）
+func NewExcelRule(title string) *ExcelRule {
+	return &ExcelRule{title: title}
+}
+
+// GetTitle 获取标题
+func (r *ExcelRule) GetTitle() string {
+	return r.title
+}
+
+// SetRequired 设置必填（表头缺失或内容为空时报错）
+func (r *ExcelRule) SetRequired(required bool) *ExcelRule {
+	r.required = required
+	return r
+}
+
+// SetMin 设置最小值
+func (r *ExcelRule) SetMin(min float64) *ExcelRule {
+	r.min = &min
+	return r
+}
+
+// SetMax 设置最大值
+func (r *ExcelRule) SetMax(max float64) *ExcelRule {
+	r.max = &max
+	return r
+}
+
+// SetRange 设置数值范围
+func (r *ExcelRule) SetRange(min, max float64) *ExcelRule {
+	return r.SetMin(min).SetMax(max)
+}
+
+// Err 获取错误（配置规则时的错误，如：正则表达式错误，Validate时同样会返回）
+func (r *ExcelRule) Err() error {
+	return r.err
+}
+
+// SetPattern 设置正则表达式（表达式错误时记录在规则上，Validate时返回）
+func (r *ExcelRule) SetPattern(pattern string) *ExcelRule {
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		r.pattern = nil
+		if r.err == nil {
+			r.err = fmt.Errorf("校验规则（%s）正则表达式错误：%w", r.title, err)
+		}
+		return r
+	}
+	r.pattern = compiled
+	return r
+}
+
+// SetEnums 设置枚举值
+func (r *ExcelRule) SetEnums(enums ...string) *ExcelRule {
+	r.enums = enums
+	return r
+}
+
+// SetUnique 设置唯一（各行之间不能重复）
+func (r *ExcelRule) SetUnique(unique bool) *ExcelRule {
+	r.unique = unique
+	return r
+}
+
+// AddCheck 增加自定义校验
+func (r *ExcelRule) AddCheck(check ExcelRuleCheck) *ExcelRule {
+	r.checks = append(r.checks, check)
+	return r
+}
+
+// check 校验单元格（不含唯一性）
+func (r *ExcelRule) check(content string, row map[string]string) []error {
+	var errs []error
+
+	if strings.TrimSpace(content) == "" {
+		if r.required {
+			errs = append(errs, errors.New("不能为空"))
+		}
+		return errs
+	}
+
+	if r.min != nil || r.max != nil {
+		if number, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(content), ",", ""), 64); err != nil {
+			errs = append(errs, errors.New("不是有效数字"))
+		} else if r.min != nil && number < *r.min {
+			errs = append(errs, fmt.Errorf("不能小于%v", *r.min))
+		} else if r.max != nil && number > *r.max {
+			errs = append(errs, fmt.Errorf("不能大于%v", *r.max))
+		}
+	}
+
+	if r.pattern != nil && !r.pattern.MatchString(content) {
+		errs = append(errs, errors.New("格式不正确"))
+	}
+
+	if len(r.enums) > 0 {
+		matched := false
+		for _, enum := range r.enums {
+			if enum == content {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			errs = append(errs, fmt.Errorf("必须为：%s", strings.Join(r.enums, "、")))
+		}
+	}
+
+	for _, check := range r.checks {
+		if err := check(content, row); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// GetRules 获取校验规则
+func (r *ExcelReader) GetRules() []*ExcelRule {
+	return r.rules
+}
+
+// SetRules 设置校验规则
+func (r *ExcelReader) SetRules(rules ...*ExcelRule) *ExcelReader {
+	r.rules = rules
+	return r
+}
+
+// AddRule 增加校验规则
+func (r *ExcelReader) AddRule(rule *ExcelRule) *ExcelReader {
+	r.rules = append(r.rules, rule)
+	return r
+}
+
+// Validate 按校验规则校验已读取的数据
+func (r *ExcelReader) Validate() *ExcelValidationReport {
+	report := &ExcelValidationReport{reader: r}
+	if r.failed() {
+		return report
+	}
+	if len(r.GetTitle()) == 0 {
+		r.fail(ErrTitleNotSet)
+		return report
+	}
+
+	columns := make(map[string]int)
+	for idx, title := range r.GetTitle() {
+		if _, exist := columns[title]; !exist {
+			columns[title] = idx
+		}
+	}
+
+	var (
+		rules    []*ExcelRule
+		uniques  = make(map[string]map[string]uint64)
+		rowsData = r.ToMap("")
+	)
+	for _, rule := range r.GetRules() {
+		if err := rule.Err(); err != nil {
+			r.fail(err)
+			return report
+		}
+		if _, exist := columns[rule.GetTitle()]; !exist {
+			if rule.required {
+				report.missingTitles = append(report.missingTitles, rule.GetTitle())
+			}
+			continue
+		}
+		rules = append(rules, rule)
+		if rule.unique {
+			uniques[rule.GetTitle()] = make(map[string]uint64)
+		}
+	}
+
+	for _, rowNumber := range r.GetRowNumbers() {
+		var (
+			row            = rowsData[rowNumber]
+			excelRowNumber = r.GetExcelRowNumber(rowNumber)
+		)
+
+		for _, rule := range rules {
+			var (
+				colNumber = columns[rule.GetTitle()] + 1
+				content   = row[rule.GetTitle()]
+			)
+
+			for _, err := range rule.check(content, row) {
+				report.errors = append(report.errors, NewCellError(excelRowNumber, colNumber, rule.GetTitle(), content, err))
+			}
+
+			if rule.unique && strings.TrimSpace(content) != "" {
+				if firstRowNumber, exist := uniques[rule.GetTitle()][content]; exist {
+					report.errors = append(report.errors, NewCellError(excelRowNumber, colNumber, rule.GetTitle(), content, fmt.Errorf("与第%d行重复", firstRowNumber)))
+				} else {
+					uniques[rule.GetTitle()][content] = excelRowNumber
+				}
+			}
+		}
+	}
+
+	return report
+}
+
+// IsValid 是否通过校验
+func (r *ExcelValidationReport) IsValid() bool {
+	return len(r.missingTitles) == 0 && len(r.errors) == 0
+}
+
+// GetMissingTitles 获取缺失的必填表头
+func (r *ExcelValidationReport) GetMissingTitles() []string {
+	return r.missingTitles
+}
+
+// GetErrors 获取单元格错误
+func (r *ExcelValidationReport) GetErrors() []*CellError {
+	return r.errors
+}
+
+// GetMessages 获取错误信息（如：第3行 列B（手机号）：格式不正确）
+func (r *ExcelValidationReport) GetMessages() []string {
+	messages := make([]string, 0, len(r.missingTitles)+len(r.errors))
+	for _, title := range r.missingTitles {
+		messages = append(messages, fmt.Sprintf("缺少表头：%s", title))
+	}
+	for _, err := range r.errors {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
+
+// Export 将校验结果导出到原文件副本：错误单元格标红并以批注说明原因
+func (r *ExcelValidationReport) Export(filename string, a ...any) *ExcelWriter {
+	writer := &ExcelWriter{collectErr: r.reader.collectErr}
+	if r.reader.excel == nil {
+		return writer.fail(ErrFileNotOpened)
+	}
+
+	var buffer bytes.Buffer
+	if err := r.reader.excel.Write(&buffer); err != nil {
+		return writer.fail(fmt.Errorf("复制文件错误：%w", err))
+	}
+	f, err := excelize.OpenReader(&buffer)
+	if err != nil {
+		return writer.fail(fmt.Errorf("复制文件错误：%w", err))
+	}
+	writer.initFile(fmt.Sprintf(filename, a...), f).ActiveSheetByName(r.reader.GetSheetName())
+
+	var (
+		coordinates []string
+		comments    = make(map[string][]string)
+	)
+	for _, cellErr := range r.errors {
+		if _, exist := comments[cellErr.Coordinate]; !exist {
+			coordinates = append(coordinates, cellErr.Coordinate)
+		}
+		comments[cellErr.Coordinate] = append(comments[cellErr.Coordinate], cellErr.Err.Error())
+	}
+
+	highlighted := make(map[int]int)
+	for _, coordinate := range coordinates {
+		styleID, err := f.GetCellStyle(writer.sheetName, coordinate)
+		if err != nil {
+			return writer.fail(NewCellErrorByCoordinate(coordinate, "", fmt.Errorf("设置样式错误：%w", err)))
+		}
+		// 仅替换填充，保留原有字体、边框及数字格式
+		highlightedStyleID, exist := highlighted[styleID]
+		if !exist {
+			excelStyle, err := f.GetStyle(styleID)
+			if err != nil {
+				return writer.fail(NewCellErrorByCoordinate(coordinate, "", fmt.Errorf("设置样式错误：%w", err)))
+			}
+			excelStyle.Fill = excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"#FFC7CE"}}
+			if highlightedStyleID, err = writer.newStyleID(excelStyle); err != nil {
+				return writer.fail(NewCellErrorByCoordinate(coordinate, "", fmt.Errorf("设置样式错误：%w", err)))
+			}
+			highlighted[styleID] = highlightedStyleID
+		}
+		if err = f.SetCellStyle(writer.sheetName, coordinate, coordinate, highlightedStyleID); err != nil {
+			return writer.fail(NewCellErrorByCoordinate(coordinate, "", fmt.Errorf("设置样式错误：%w", err)))
+		}
+		comment := excelize.Comment{Cell: coordinate, Author: "校验", Text: strings.Join(comments[coordinate], "\n")}
+		if err = f.AddComment(writer.sheetName, comment); err != nil {
+			return writer.fail(NewCellErrorByCoordinate(coordinate, "", fmt.Errorf("添加批注错误：%w", err)))
+		}
+	}
+
+	return writer
+}
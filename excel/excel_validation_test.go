@@ -0,0 +1,30 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateInvalidPattern(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "validation.xlsx")
+	writer := NewExcelWriter(filename).SetCollectErr(true).ActiveSheetByIndex(0).
+		SetTitleRow([]string{"编码"}, 1).
+		AddRow(NewExcelRow().SetRowNumber(2).SetCells([]*ExcelCell{NewExcelCellAny("A1")}))
+	if err := writer.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	rule := NewExcelRule("编码").SetPattern("[A-Z")
+	if rule.Err() == nil {
+		t.Fatal("SetPattern: got nil error for invalid pattern")
+	}
+
+	reader := NewExcelReader().SetCollectErr(true).SetTitleRow(1).SetOriginalRow(2).AutoRead(filename)
+	if err := reader.Err(); err != nil {
+		t.Fatalf("AutoRead: %v", err)
+	}
+	reader.AddRule(rule).Validate()
+	if reader.Err() != rule.Err() {
+		t.Errorf("Validate: got %v, want %v", reader.Err(), rule.Err())
+	}
+}
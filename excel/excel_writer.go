@@ -1,17 +1,35 @@
 package excel
 
 import (
-	"errors"
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"strconv"
+	"time"
 
 	"github.com/xuri/excelize/v2"
 )
 
+// excelFloatPrecision 写入小数时保留的位数（普通写入与流式写入一致）
+const excelFloatPrecision = 4
+
 // ExcelWriter Excel写入器
 type ExcelWriter struct {
-	filename  string
-	excel     *excelize.File
-	sheetName string
+	filename      string
+	excel         *excelize.File
+	sheetName     string
+	styles        map[string]int
+	formatStyles  map[string]int
+	iconSets      map[int]string
+	titleRows     map[string]uint64
+	numberFormats map[ExcelCellContentType]string
+	stream        *excelize.StreamWriter
+	streamRow     uint64
+	csvDelimiter  rune
+	csvEncoding   string
+	collectErr    bool
+	err           error
 }
 
 // NewExcelWriter 初始化
@@ -19,6 +37,35 @@ func NewExcelWriter(filename string, a ...any) *ExcelWriter {
 	return (&ExcelWriter{}).Init(fmt.Sprintf(filename, a...))
 }
 
+// SetCollectErr 设置错误收集模式（开启后出错不再panic，通过Err获取第一个错误，后续操作不再执行）
+//
+//	new(ExcelWriter).SetCollectErr(true).Init(filename)
+func (r *ExcelWriter) SetCollectErr(collectErr bool) *ExcelWriter {
+	r.collectErr = collectErr
+	return r
+}
+
+// Err 获取错误
+func (r *ExcelWriter) Err() error {
+	return r.err
+}
+
+// fail 记录错误（未开启错误收集模式时panic）
+func (r *ExcelWriter) fail(err error) *ExcelWriter {
+	if r.err == nil {
+		r.err = err
+	}
+	if !r.collectErr {
+		panic(err)
+	}
+	return r
+}
+
+// failed 错误收集模式下是否已出错
+func (r *ExcelWriter) failed() bool {
+	return r.collectErr && r.err != nil
+}
+
 // GetFilename 获取文件名
 func (r *ExcelWriter) GetFilename() string {
 	return r.filename
@@ -33,20 +80,56 @@ func (r *ExcelWriter) SetFilename(filename string) *ExcelWriter {
 // Init 初始化
 func (r *ExcelWriter) Init(filename string) *ExcelWriter {
 	if filename == "" {
-		panic(errors.New("文件名不能为空"))
+		return r.fail(ErrFilenameEmpty)
 	}
+	return r.initFile(filename, excelize.NewFile())
+}
+
+// initFile 使用已有文件初始化
+func (r *ExcelWriter) initFile(filename string, f *excelize.File) *ExcelWriter {
 	r.filename = filename
-	r.excel = excelize.NewFile()
+	r.excel = f
+	r.sheetName = f.GetSheetName(f.GetActiveSheetIndex())
+	r.styles = make(map[string]int)
+	r.formatStyles = make(map[string]int)
+	r.iconSets = make(map[int]string)
+	r.titleRows = make(map[string]uint64)
+	r.numberFormats = map[ExcelCellContentType]string{
+		ExcelCellContentTypeDate:     ExcelNumberFormatDate,
+		ExcelCellContentTypeDateTime: ExcelNumberFormatDateTime,
+		ExcelCellContentTypeTime:     ExcelNumberFormatTime,
+		ExcelCellContentTypeDuration: ExcelNumberFormatDuration,
+	}
 
 	return r
 }
 
+// GetDefaultNumberFormat 获取单元格类型的默认数字格式
+func (r *ExcelWriter) GetDefaultNumberFormat(contentType ExcelCellContentType) string {
+	return r.numberFormats[contentType]
+}
+
+// SetDefaultNumberFormat 设置单元格类型的默认数字格式（单元格未设置数字格式时使用，如日期：yyyy年m月d日）
+func (r *ExcelWriter) SetDefaultNumberFormat(contentType ExcelCellContentType, numberFormat string) *ExcelWriter {
+	r.numberFormats[contentType] = numberFormat
+	return r
+}
+
 // CreateSheet 创建工作表
 func (r *ExcelWriter) CreateSheet(sheetName string) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if r.stream != nil {
+		r.Flush()
+	}
 	if sheetName == "" {
-		panic(errors.New("工作表名称不能为空"))
+		return r.fail(ErrSheetNameEmpty)
+	}
+	sheetIndex, err := r.excel.NewSheet(sheetName)
+	if err != nil {
+		return r.fail(fmt.Errorf("创建工作表错误：%w", err))
 	}
-	sheetIndex := r.excel.NewSheet(sheetName)
 	r.excel.SetActiveSheet(sheetIndex)
 	r.sheetName = r.excel.GetSheetName(sheetIndex)
 
@@ -55,10 +138,19 @@ func (r *ExcelWriter) CreateSheet(sheetName string) *ExcelWriter {
 
 // ActiveSheetByName 选择工作表（根据名称）
 func (r *ExcelWriter) ActiveSheetByName(sheetName string) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if r.stream != nil {
+		r.Flush()
+	}
 	if sheetName == "" {
-		panic(errors.New("工作表名称不能为空"))
+		return r.fail(ErrSheetNameEmpty)
+	}
+	sheetIndex, err := r.excel.GetSheetIndex(sheetName)
+	if err != nil || sheetIndex == -1 {
+		return r.fail(fmt.Errorf("%w：%s", ErrSheetNotFound, sheetName))
 	}
-	sheetIndex := r.excel.GetSheetIndex(sheetName)
 	r.excel.SetActiveSheet(sheetIndex)
 	r.sheetName = sheetName
 
@@ -67,31 +159,130 @@ func (r *ExcelWriter) ActiveSheetByName(sheetName string) *ExcelWriter {
 
 // ActiveSheetByIndex 选择工作表（根据编号）
 func (r *ExcelWriter) ActiveSheetByIndex(sheetIndex int) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if r.stream != nil {
+		r.Flush()
+	}
 	if sheetIndex < 0 {
-		panic(errors.New("工作表索引不能小于0"))
+		return r.fail(ErrSheetIndexInvalid)
+	}
+	if r.excel.GetSheetName(sheetIndex) == "" {
+		return r.fail(fmt.Errorf("%w：%d", ErrSheetNotFound, sheetIndex))
 	}
 	r.excel.SetActiveSheet(sheetIndex)
 	r.sheetName = r.excel.GetSheetName(sheetIndex)
 	return r
 }
 
-// setStyleFont 设置字体
-func (r *ExcelWriter) setStyleFont(cell *ExcelCell) {
-	if style, err := r.excel.NewStyle(&excelize.Style{
+// newExcelizeStyle 根据单元格生成样式
+func newExcelizeStyle(cell *ExcelCell) *excelize.Style {
+	excelStyle := &excelize.Style{
 		Font: &excelize.Font{
-			Bold:   cell.GetFontBold(),
-			Italic: cell.GetFontItalic(),
-			Family: cell.GetFontFamily(),
-			Size:   cell.GetFontSize(),
-			Color:  cell.GetFontColor(),
+			Bold:      cell.GetFontBold(),
+			Italic:    cell.GetFontItalic(),
+			Underline: cell.GetFontUnderline(),
+			Family:    cell.GetFontFamily(),
+			Size:      cell.GetFontSize(),
+			Strike:    cell.GetFontStrike(),
+			Color:     cell.GetFontColor(),
 		},
-	}); err != nil {
-		panic(fmt.Errorf("设置字体错误：%s", cell.GetCoordinate()))
+	}
+	if fillColor := cell.GetFillColor(); fillColor != "" {
+		excelStyle.Fill = excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{fillColor}}
+	}
+	for _, side := range excelBorderSides {
+		for _, border := range cell.GetBorders() {
+			if border.Side == side {
+				excelStyle.Border = append(excelStyle.Border, excelize.Border{Type: border.Side, Color: border.Color, Style: border.Style})
+			}
+		}
+	}
+	if cell.GetHorizontalAlignment() != "" || cell.GetVerticalAlignment() != "" || cell.GetWrapText() || cell.GetIndent() > 0 {
+		excelStyle.Alignment = &excelize.Alignment{
+			Horizontal: cell.GetHorizontalAlignment(),
+			Vertical:   cell.GetVerticalAlignment(),
+			WrapText:   cell.GetWrapText(),
+			Indent:     cell.GetIndent(),
+		}
+	}
+	if numberFormat := cell.GetNumberFormat(); numberFormat != "" {
+		excelStyle.CustomNumFmt = &numberFormat
+	}
+	if cell.GetHyperlink() != "" {
+		if excelStyle.Font.Color == "" {
+			excelStyle.Font.Color = "#0563C1"
+		}
+		if excelStyle.Font.Underline == "" {
+			excelStyle.Font.Underline = ExcelUnderlineSingle
+		}
+	}
+	if cell.GetUnlocked() {
+		excelStyle.Protection = &excelize.Protection{Locked: false}
+	}
+
+	return excelStyle
+}
+
+// getStyleID 获取样式编号（相同样式只创建一次）
+func (r *ExcelWriter) getStyleID(cell *ExcelCell) (int, error) {
+	excelStyle := newExcelizeStyle(cell)
+	if numberFormat := r.GetDefaultNumberFormat(cell.GetContentType()); excelStyle.CustomNumFmt == nil && numberFormat != "" {
+		excelStyle.CustomNumFmt = &numberFormat
+	}
+
+	return r.newStyleID(excelStyle)
+}
+
+// newStyleID 根据样式获取样式编号（相同样式只创建一次）
+func (r *ExcelWriter) newStyleID(excelStyle *excelize.Style) (int, error) {
+	key, err := json.Marshal(excelStyle)
+	if err != nil {
+		return 0, err
+	}
+	if styleID, exist := r.styles[string(key)]; exist {
+		return styleID, nil
+	}
+
+	styleID, err := r.excel.NewStyle(excelStyle)
+	if err != nil {
+		return 0, err
+	}
+	r.styles[string(key)] = styleID
+
+	return styleID, nil
+}
+
+// setStyleFont 设置字体
+func (r *ExcelWriter) setStyleFont(cell *ExcelCell) error {
+	if style, err := r.getStyleID(cell); err != nil {
+		return NewCellErrorByCoordinate(cell.GetCoordinate(), cell.GetContent(), fmt.Errorf("设置字体错误：%w", err))
 	} else {
 		if err = r.excel.SetCellStyle(r.sheetName, cell.GetCoordinate(), cell.GetCoordinate(), style); err != nil {
-			panic(fmt.Errorf("设置字体错误：%s", cell.GetCoordinate()))
+			return NewCellErrorByCoordinate(cell.GetCoordinate(), cell.GetContent(), fmt.Errorf("设置字体错误：%w", err))
 		}
 	}
+
+	return nil
+}
+
+// setColWidth 设置列宽（流式写入模式下须在写入行之前设置）
+func (r *ExcelWriter) setColWidth(startCol, endCol int, width float64) error {
+	if r.stream != nil {
+		return r.stream.SetColWidth(startCol, endCol, width)
+	}
+
+	startColText, err := ColumnNumberToText(startCol)
+	if err != nil {
+		return err
+	}
+	endColText, err := ColumnNumberToText(endCol)
+	if err != nil {
+		return err
+	}
+
+	return r.excel.SetColWidth(r.sheetName, startColText, endColText, width)
 }
 
 // SetRows 设置行数据
@@ -102,32 +293,113 @@ func (r *ExcelWriter) SetRows(excelRows []*ExcelRow) *ExcelWriter {
 	return r
 }
 
+// roundExcelFloat 按excelFloatPrecision四舍五入（与SetCellFloat的处理一致）
+func roundExcelFloat(f float64) float64 {
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(f, 'f', excelFloatPrecision, 64), 64)
+	if err != nil {
+		return f
+	}
+	return rounded
+}
+
+// setCell 写入单元格
+func (r *ExcelWriter) setCell(cell *ExcelCell) error {
+	var (
+		err   error
+		label string
+	)
+
+	switch cell.GetContentType() {
+	case ExcelCellContentTypeFormula:
+		label = "公式"
+		if content, ok := cell.GetContent().(string); !ok {
+			err = ErrCellContentInvalid
+		} else {
+			err = r.excel.SetCellFormula(r.sheetName, cell.GetCoordinate(), content)
+		}
+	case ExcelCellContentTypeInt:
+		label = "数字"
+		if content, ok := cell.GetContent().(int); !ok {
+			err = ErrCellContentInvalid
+		} else {
+			err = r.excel.SetCellInt(r.sheetName, cell.GetCoordinate(), content)
+		}
+	case ExcelCellContentTypeFloat64:
+		label = "小数"
+		if content, ok := cell.GetContent().(float64); !ok {
+			err = ErrCellContentInvalid
+		} else {
+			err = r.excel.SetCellFloat(r.sheetName, cell.GetCoordinate(), content, excelFloatPrecision, 64)
+		}
+	case ExcelCellContentTypeBool:
+		label = "布尔"
+		if content, ok := cell.GetContent().(bool); !ok {
+			err = ErrCellContentInvalid
+		} else {
+			err = r.excel.SetCellBool(r.sheetName, cell.GetCoordinate(), content)
+		}
+	case ExcelCellContentTypeDate, ExcelCellContentTypeDateTime, ExcelCellContentTypeTime:
+		label = "日期"
+		if content, ok := cell.GetContent().(time.Time); !ok {
+			err = ErrCellContentInvalid
+		} else {
+			err = r.excel.SetCellValue(r.sheetName, cell.GetCoordinate(), excelTimeValue(content))
+		}
+	case ExcelCellContentTypeDuration:
+		label = "时长"
+		if content, ok := cell.GetContent().(time.Duration); !ok {
+			err = ErrCellContentInvalid
+		} else {
+			err = r.excel.SetCellFloat(r.sheetName, cell.GetCoordinate(), durationToExcelDays(content), -1, 64)
+		}
+	case ExcelCellContentTypeImage:
+		label = "图片"
+		if content, ok := cell.GetContent().([]byte); !ok {
+			err = ErrCellContentInvalid
+		} else {
+			err = r.addImage(cell.GetCoordinate(), content)
+		}
+	case ExcelCellContentTypeRichText:
+		label = "富文本"
+		if content, ok := cell.GetContent().([]*ExcelRichTextRun); !ok {
+			err = ErrCellContentInvalid
+		} else {
+			err = r.excel.SetCellRichText(r.sheetName, cell.GetCoordinate(), newExcelizeRichText(content))
+		}
+	default:
+		label = "默认"
+		err = r.excel.SetCellValue(r.sheetName, cell.GetCoordinate(), cell.GetContent())
+	}
+
+	if err != nil {
+		return NewCellErrorByCoordinate(cell.GetCoordinate(), cell.GetContent(), fmt.Errorf("写入数据错误（%s）：%w", label, err))
+	}
+
+	return nil
+}
+
 // AddRow 增加一行行数据
 func (r *ExcelWriter) AddRow(excelRow *ExcelRow) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if err := excelRow.Err(); err != nil {
+		return r.fail(err)
+	}
+	if r.stream != nil {
+		return r.addStreamRow(excelRow)
+	}
+
 	for _, cell := range excelRow.GetCells() {
-		switch cell.GetContentType() {
-		case ExcelCellContentTypeFormula:
-			if err := r.excel.SetCellFormula(r.sheetName, cell.GetCoordinate(), cell.GetContent().(string)); err != nil {
-				panic(fmt.Errorf("写入数据错误（公式）%s %s：%v", cell.GetCoordinate(), cell.GetContent(), err.Error()))
-			}
-		case ExcelCellContentTypeInt:
-			if err := r.excel.SetCellInt(r.sheetName, cell.GetCoordinate(), cell.GetContent().(int)); err != nil {
-				panic(fmt.Errorf("写入数据错误（数字）%s %s：%v", cell.GetCoordinate(), cell.GetContent(), err.Error()))
-			}
-		case ExcelCellContentTypeFloat64:
-			if err := r.excel.SetCellFloat(r.sheetName, cell.GetCoordinate(), cell.GetContent().(float64), 4, 64); err != nil {
-				panic(fmt.Errorf("写入数据错误（小数）%s %s：%v", cell.GetCoordinate(), cell.GetContent(), err.Error()))
-			}
-		case ExcelCellContentTypeBool:
-			if err := r.excel.SetCellBool(r.sheetName, cell.GetCoordinate(), cell.GetContent().(bool)); err != nil {
-				panic(fmt.Errorf("写入数据错误（布尔）%s %s：%v", cell.GetCoordinate(), cell.GetContent(), err.Error()))
-			}
-		default:
-			if err := r.excel.SetCellValue(r.sheetName, cell.GetCoordinate(), cell.GetContent()); err != nil {
-				panic(fmt.Errorf("写入数据错误（默认）%s %s：%v", cell.GetCoordinate(), cell.GetContent(), err.Error()))
-			}
+		if err := r.setCell(cell); err != nil {
+			return r.fail(err)
+		}
+		if err := r.setStyleFont(cell); err != nil {
+			return r.fail(err)
+		}
+		if err := r.setCellExtras(cell); err != nil {
+			return r.fail(err)
 		}
-		r.setStyleFont(cell)
 	}
 
 	return r
@@ -145,27 +417,69 @@ func (r *ExcelWriter) SetTitleRow(titles []string, rowNumber uint64) *ExcelWrite
 			titleCells[idx] = NewExcelCellAny(title)
 		}
 
-		titleRow = NewExcelRow().SetRowNumber(rowNumber).SetCells(titleCells)
+		titleRow = NewExcelRow().SetCollectErr(r.collectErr).SetRowNumber(rowNumber).SetCells(titleCells)
 
 		r.AddRow(titleRow)
+		r.titleRows[r.sheetName] = rowNumber
 	}
 
 	return r
 }
 
-// Save 保存文件
+// Save 保存文件（.csv、.tsv文件按CSV保存当前工作表）
 func (r *ExcelWriter) Save() error {
+	if r.failed() {
+		return r.err
+	}
 	if r.filename == "" {
-		panic(errors.New("未设置文件名"))
+		r.fail(ErrFilenameEmpty)
+		return ErrFilenameEmpty
+	}
+	if IsCSVFile(r.filename) {
+		return r.SaveCSV("%s", r.filename)
+	}
+	if r.stream != nil {
+		if r.Flush(); r.failed() {
+			return r.err
+		}
 	}
 	return r.excel.SaveAs(r.filename)
 }
 
-// Download 下载Excel
-// func (r *ExcelWriter) Download(ctx *gin.Context) error {
-// 	ctx.Writer.Header().Set("Content-Type", "application/octet-stream")
-// 	ctx.Writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", url.QueryEscape(r.filename)))
-// 	ctx.Writer.Header().Set("Content-Transfer-Encoding", "binary")
-// 	ctx.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Disposition")
-// 	return r.excel.Write(ctx.Writer)
-// }
+// WriteTo 写入io.Writer（实现io.WriterTo）
+func (r *ExcelWriter) WriteTo(w io.Writer) (int64, error) {
+	if r.failed() {
+		return 0, r.err
+	}
+	if r.stream != nil {
+		if r.Flush(); r.failed() {
+			return 0, r.err
+		}
+	}
+
+	counter := &countWriter{writer: w}
+	err := r.excel.Write(counter)
+	return counter.count, err
+}
+
+// Bytes 获取文件内容
+func (r *ExcelWriter) Bytes() ([]byte, error) {
+	var buffer bytes.Buffer
+	if _, err := r.WriteTo(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// countWriter 统计写入字节数
+type countWriter struct {
+	writer io.Writer
+	count  int64
+}
+
+// Write 实现io.Writer接口
+func (r *countWriter) Write(p []byte) (int, error) {
+	n, err := r.writer.Write(p)
+	r.count += int64(n)
+	return n, err
+}
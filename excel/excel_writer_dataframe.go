@@ -0,0 +1,158 @@
+package excel
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/go-gota/gota/dataframe"
+	"github.com/go-gota/gota/series"
+)
+
+type (
+	// ExcelCellStyler 单元格样式设置函数（返回设置样式后的单元格）
+	ExcelCellStyler func(cell *ExcelCell) *ExcelCell
+
+	// WriteDataFrameOption DataFrame写入选项
+	WriteDataFrameOption struct {
+		titleRow      uint64
+		nullValue     any
+		numberFormats map[string]string
+		widths        map[string]float64
+		stylers       map[string]ExcelCellStyler
+	}
+)
+
+// NewWriteDataFrameOption 构造函数（默认第一行是表头，空值及NaN写入空单元格）
+func NewWriteDataFrameOption() *WriteDataFrameOption {
+	return &WriteDataFrameOption{
+		titleRow:      1,
+		numberFormats: make(map[string]string),
+		widths:        make(map[string]float64),
+		stylers:       make(map[string]ExcelCellStyler),
+	}
+}
+
+// GetTitleRow 获取表头行
+func (r *WriteDataFrameOption) GetTitleRow() uint64 {
+	return r.titleRow
+}
+
+// SetTitleRow 设置表头行
+func (r *WriteDataFrameOption) SetTitleRow(titleRow uint64) *WriteDataFrameOption {
+	r.titleRow = titleRow
+	return r
+}
+
+// GetNullValue 获取空值（NaN、±Inf）的替代值
+func (r *WriteDataFrameOption) GetNullValue() any {
+	return r.nullValue
+}
+
+// SetNullValue 设置空值（NaN、±Inf）的替代值（如：0、"-"，nil为空单元格）
+func (r *WriteDataFrameOption) SetNullValue(nullValue any) *WriteDataFrameOption {
+	r.nullValue = nullValue
+	return r
+}
+
+// SetNumberFormat 设置列数字格式（根据列名）
+func (r *WriteDataFrameOption) SetNumberFormat(name, numberFormat string) *WriteDataFrameOption {
+	r.numberFormats[name] = numberFormat
+	return r
+}
+
+// SetWidth 设置列宽（根据列名）
+func (r *WriteDataFrameOption) SetWidth(name string, width float64) *WriteDataFrameOption {
+	r.widths[name] = width
+	return r
+}
+
+// SetStyler 设置列样式（根据列名，对该列每个数据单元格调用）
+//
+//	opt.SetStyler("金额", func(cell *ExcelCell) *ExcelCell { return cell.SetFontColor("#FF0000", true) })
+func (r *WriteDataFrameOption) SetStyler(name string, styler ExcelCellStyler) *WriteDataFrameOption {
+	r.stylers[name] = styler
+	return r
+}
+
+// GetStyler 获取列样式
+func (r *WriteDataFrameOption) GetStyler(name string) ExcelCellStyler {
+	return r.stylers[name]
+}
+
+// WriteDataFrame 写入DataFrame（列名为表头，Int、Float、Bool、String列分别写入整数、小数、布尔、字符串单元格）
+func (r *ExcelWriter) WriteDataFrame(df dataframe.DataFrame, opt *WriteDataFrameOption) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if df.Err != nil {
+		return r.fail(fmt.Errorf("DataFrame错误：%w", df.Err))
+	}
+	if opt == nil {
+		opt = NewWriteDataFrameOption()
+	}
+
+	var (
+		names   = df.Names()
+		columns = make([]series.Series, len(names))
+	)
+	for idx, name := range names {
+		columns[idx] = df.Col(name)
+		if width, exist := opt.widths[name]; exist {
+			if err := r.setColWidth(idx+1, idx+1, width); err != nil {
+				return r.fail(fmt.Errorf("设置列宽错误：%w", err))
+			}
+		}
+	}
+	r.SetTitleRow(names, opt.GetTitleRow())
+
+	for rowIdx := 0; rowIdx < df.Nrow(); rowIdx++ {
+		cells := make([]*ExcelCell, len(columns))
+		for colIdx, column := range columns {
+			cell, err := newExcelCellByElement(column.Elem(rowIdx), column.Type(), opt.nullValue)
+			if err != nil {
+				return r.fail(NewCellError(opt.GetTitleRow()+uint64(rowIdx)+1, colIdx+1, names[colIdx], column.Elem(rowIdx).String(), err))
+			}
+			if numberFormat, exist := opt.numberFormats[names[colIdx]]; exist {
+				cell.SetNumberFormat(numberFormat)
+			}
+			if styler := opt.GetStyler(names[colIdx]); styler != nil {
+				cell = styler(cell)
+			}
+			cells[colIdx] = cell
+		}
+
+		r.AddRow(NewExcelRow().SetCollectErr(r.collectErr).SetRowNumber(opt.GetTitleRow() + uint64(rowIdx) + 1).SetCells(cells))
+	}
+
+	return r
+}
+
+// newExcelCellByElement 根据DataFrame元素类型创建单元格
+func newExcelCellByElement(element series.Element, seriesType series.Type, nullValue any) (*ExcelCell, error) {
+	if element.IsNA() {
+		return newExcelCellByValue(nullValue), nil
+	}
+
+	switch seriesType {
+	case series.Int:
+		value, err := element.Int()
+		if err != nil {
+			return nil, err
+		}
+		return NewExcelCellInt(value), nil
+	case series.Float:
+		value := element.Float()
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return newExcelCellByValue(nullValue), nil
+		}
+		return NewExcelCellFloat64(value), nil
+	case series.Bool:
+		value, err := element.Bool()
+		if err != nil {
+			return nil, err
+		}
+		return NewExcelCellBool(value), nil
+	default:
+		return NewExcelCellAny(element.String()), nil
+	}
+}
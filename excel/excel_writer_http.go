@@ -0,0 +1,100 @@
+package excel
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// ContentTypeXlsx xlsx文件的Content-Type
+	ContentTypeXlsx = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	// ContentTypeCSV csv文件的Content-Type
+	ContentTypeCSV = "text/csv"
+)
+
+// ContentDisposition 生成附件下载的Content-Disposition（非ASCII文件名按RFC 5987编码）
+func ContentDisposition(filename string) string {
+	var (
+		fallback strings.Builder
+		encoded  strings.Builder
+	)
+
+	filename = filepath.Base(filename)
+	for _, char := range filename {
+		if char < 0x80 && char != '"' && char != '\\' && char >= 0x20 {
+			fallback.WriteRune(char)
+		} else {
+			fallback.WriteRune('_')
+		}
+	}
+	for _, b := range []byte(filename) {
+		if isAttrChar(b) {
+			encoded.WriteByte(b)
+		} else {
+			encoded.WriteString(fmt.Sprintf("%%%02X", b))
+		}
+	}
+
+	return fmt.Sprintf(`attachment; filename="%s"; filename*=UTF-8''%s`, fallback.String(), encoded.String())
+}
+
+// isAttrChar RFC 5987 attr-char
+func isAttrChar(b byte) bool {
+	if 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9' {
+		return true
+	}
+	return strings.IndexByte("!#$&+-.^_`|~", b) >= 0
+}
+
+// Download 下载Excel（写入http.ResponseWriter，gin等框架可传入ctx.Writer；文件名为.csv时同DownloadCSV）
+func (r *ExcelWriter) Download(w http.ResponseWriter) error {
+	if IsCSVFile(r.filename) {
+		return r.DownloadCSV(w)
+	}
+
+	content, err := r.Bytes()
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", ContentTypeXlsx)
+	w.Header().Set("Content-Disposition", ContentDisposition(r.filename))
+	w.Header().Set("Content-Transfer-Encoding", "binary")
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(content)))
+	w.Header().Set("Access-Control-Expose-Headers", "Content-Disposition")
+	_, err = w.Write(content)
+
+	return err
+}
+
+// DownloadCSV 以CSV格式下载当前工作表（文件扩展名替换为.csv，编码通过SetCSVEncoding设置）
+func (r *ExcelWriter) DownloadCSV(w http.ResponseWriter) error {
+	var buffer bytes.Buffer
+	if _, err := r.WriteCSVTo(&buffer); err != nil {
+		return err
+	}
+
+	charset := "utf-8"
+	if r.csvEncoding == ExcelCSVEncodingGBK || r.csvEncoding == ExcelCSVEncodingGB18030 {
+		charset = r.csvEncoding
+	}
+	filename := strings.TrimSuffix(r.filename, filepath.Ext(r.filename)) + ".csv"
+
+	w.Header().Set("Content-Type", ContentTypeCSV+"; charset="+charset)
+	w.Header().Set("Content-Disposition", ContentDisposition(filename))
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", buffer.Len()))
+	w.Header().Set("Access-Control-Expose-Headers", "Content-Disposition")
+	_, err := w.Write(buffer.Bytes())
+
+	return err
+}
+
+// ServeHTTP 实现http.Handler接口
+func (r *ExcelWriter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	if err := r.Download(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,22 @@
+package excel
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDownloadCSVFilename(t *testing.T) {
+	writer := NewExcelWriter("报表.csv").SetCollectErr(true).ActiveSheetByIndex(0).SetTitleRow([]string{"名称", "数量"}, 1)
+
+	recorder := httptest.NewRecorder()
+	if err := writer.Download(recorder); err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if got := recorder.Header().Get("Content-Type"); !strings.HasPrefix(got, ContentTypeCSV) {
+		t.Errorf("Content-Type: got %q, want %s", got, ContentTypeCSV)
+	}
+	if got := recorder.Body.String(); !strings.Contains(got, "名称,数量") {
+		t.Errorf("Body: got %q, want CSV content", got)
+	}
+}
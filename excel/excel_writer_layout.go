@@ -0,0 +1,261 @@
+package excel
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/width"
+)
+
+// MergeCells 合并单元格（如：A1、C3）
+func (r *ExcelWriter) MergeCells(hCell, vCell string) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+
+	var err error
+	if r.stream != nil {
+		err = r.stream.MergeCell(hCell, vCell)
+	} else {
+		err = r.excel.MergeCell(r.sheetName, hCell, vCell)
+	}
+	if err != nil {
+		return r.fail(fmt.Errorf("合并单元格错误（%s:%s）：%w", hCell, vCell, err))
+	}
+
+	return r
+}
+
+// SetMultiTitleRows 设置多级表头：同一行中相邻且上级相同的标题横向合并，下方为空字符串的单元格纵向合并
+//
+//	[][]string{
+//		{"姓名", "收入", "收入"},
+//		{"", "一季度", "二季度"},
+//	}
+func (r *ExcelWriter) SetMultiTitleRows(titles [][]string, rowNumber uint64) *ExcelWriter {
+	if r.failed() || len(titles) == 0 {
+		return r
+	}
+
+	for idx, titleRow := range titles {
+		cells := make([]*ExcelCell, len(titleRow))
+		for colIdx, title := range titleRow {
+			cells[colIdx] = NewExcelCellAny(title).
+				SetFontBold(true, true).
+				SetHorizontalAlignment(ExcelHorizontalCenter, true).
+				SetVerticalAlignment(ExcelVerticalCenter, true)
+		}
+		r.AddRow(NewExcelRow().SetCollectErr(r.collectErr).SetRowNumber(rowNumber + uint64(idx)).SetCells(cells))
+	}
+
+	titleAt := func(row, col int) string {
+		if row < len(titles) && col < len(titles[row]) {
+			return titles[row][col]
+		}
+		return ""
+	}
+	covered := make(map[[2]int]bool)
+
+	for rowIdx, titleRow := range titles {
+		for colIdx := 0; colIdx < len(titleRow); colIdx++ {
+			title := titleRow[colIdx]
+			if title == "" || covered[[2]int{rowIdx, colIdx}] {
+				continue
+			}
+
+			lastCol := colIdx
+			for lastCol+1 < len(titleRow) && titleRow[lastCol+1] == title && (rowIdx == 0 || titleAt(rowIdx-1, lastCol+1) == titleAt(rowIdx-1, colIdx)) {
+				lastCol++
+			}
+
+			lastRow := rowIdx
+			for lastRow+1 < len(titles) {
+				empty := true
+				for col := colIdx; col <= lastCol; col++ {
+					if titleAt(lastRow+1, col) != "" {
+						empty = false
+						break
+					}
+				}
+				if !empty {
+					break
+				}
+				lastRow++
+			}
+
+			for row := rowIdx; row <= lastRow; row++ {
+				for col := colIdx; col <= lastCol; col++ {
+					covered[[2]int{row, col}] = true
+				}
+			}
+
+			if lastRow > rowIdx || lastCol > colIdx {
+				r.MergeCells(NewExcelCellRef(colIdx+1, int(rowNumber)+rowIdx).Coordinate(), NewExcelCellRef(lastCol+1, int(rowNumber)+lastRow).Coordinate())
+			}
+			colIdx = lastCol
+		}
+	}
+
+	return r
+}
+
+// SetColWidth 设置列宽（列号从1开始，流式写入模式下须在写入行之前设置）
+func (r *ExcelWriter) SetColWidth(startCol, endCol int, width float64) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if err := r.setColWidth(startCol, endCol, width); err != nil {
+		return r.fail(fmt.Errorf("设置列宽错误：%w", err))
+	}
+	return r
+}
+
+// AutoFitColWidth 根据内容自动设置列宽（中文等全角字符按两个字符宽度计算），minWidth、maxWidth为0时不限制
+func (r *ExcelWriter) AutoFitColWidth(minWidth, maxWidth float64) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if r.stream != nil {
+		return r.fail(ErrStreamUnsupported)
+	}
+
+	rows, err := r.excel.GetRows(r.sheetName)
+	if err != nil {
+		return r.fail(fmt.Errorf("读取数据错误：%w", err))
+	}
+
+	var widths []int
+	for _, row := range rows {
+		for colIdx, content := range row {
+			for len(widths) <= colIdx {
+				widths = append(widths, 0)
+			}
+			if w := DisplayWidth(content); w > widths[colIdx] {
+				widths[colIdx] = w
+			}
+		}
+	}
+
+	for colIdx, w := range widths {
+		colWidth := float64(w) + 2
+		if minWidth > 0 && colWidth < minWidth {
+			colWidth = minWidth
+		}
+		if maxWidth > 0 && colWidth > maxWidth {
+			colWidth = maxWidth
+		}
+		if colWidth > excelize.MaxColumnWidth {
+			colWidth = excelize.MaxColumnWidth
+		}
+		if err = r.setColWidth(colIdx+1, colIdx+1, colWidth); err != nil {
+			return r.fail(fmt.Errorf("设置列宽错误：%w", err))
+		}
+	}
+
+	return r
+}
+
+// DisplayWidth 计算文字显示宽度（全角字符计2）
+func DisplayWidth(content string) int {
+	w := 0
+	for len(content) > 0 {
+		char, size := utf8.DecodeRuneInString(content)
+		content = content[size:]
+		switch width.LookupRune(char).Kind() {
+		case width.EastAsianWide, width.EastAsianFullwidth:
+			w += 2
+		default:
+			w++
+		}
+	}
+	return w
+}
+
+// SetRowHeight 设置行高
+func (r *ExcelWriter) SetRowHeight(rowNumber uint64, height float64) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if r.stream != nil {
+		return r.fail(ErrStreamUnsupported)
+	}
+	if err := r.excel.SetRowHeight(r.sheetName, int(rowNumber), height); err != nil {
+		return r.fail(fmt.Errorf("设置行高错误：%w", err))
+	}
+	return r
+}
+
+// FreezePanes 冻结窗格（冻结前rows行、前cols列，均为0时取消冻结）
+func (r *ExcelWriter) FreezePanes(rows, cols int) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if r.stream != nil {
+		return r.fail(ErrStreamUnsupported)
+	}
+
+	panes := &excelize.Panes{}
+	if rows > 0 || cols > 0 {
+		topLeft := NewExcelCellRef(cols+1, rows+1)
+		if err := topLeft.Validate(); err != nil {
+			return r.fail(fmt.Errorf("冻结窗格错误：%w", err))
+		}
+
+		activePane := "bottomRight"
+		if cols == 0 {
+			activePane = "bottomLeft"
+		} else if rows == 0 {
+			activePane = "topRight"
+		}
+
+		panes = &excelize.Panes{
+			Freeze:      true,
+			XSplit:      cols,
+			YSplit:      rows,
+			TopLeftCell: topLeft.Coordinate(),
+			ActivePane:  activePane,
+			Selection:   []excelize.Selection{{SQRef: topLeft.Coordinate(), ActiveCell: topLeft.Coordinate(), Pane: activePane}},
+		}
+	}
+
+	if err := r.excel.SetPanes(r.sheetName, panes); err != nil {
+		return r.fail(fmt.Errorf("冻结窗格错误：%w", err))
+	}
+
+	return r
+}
+
+// AutoFilter 设置自动筛选（如：A1、D1）
+func (r *ExcelWriter) AutoFilter(hCell, vCell string) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if r.stream != nil {
+		return r.fail(ErrStreamUnsupported)
+	}
+	if err := r.excel.AutoFilter(r.sheetName, hCell+":"+vCell, nil); err != nil {
+		return r.fail(fmt.Errorf("设置自动筛选错误：%w", err))
+	}
+	return r
+}
+
+// AutoFilterTitleRow 在表头行上设置自动筛选（范围覆盖表头及以下全部数据）
+func (r *ExcelWriter) AutoFilterTitleRow(rowNumber uint64) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if r.stream != nil {
+		return r.fail(ErrStreamUnsupported)
+	}
+
+	rows, err := r.excel.GetRows(r.sheetName)
+	if err != nil {
+		return r.fail(fmt.Errorf("读取数据错误：%w", err))
+	}
+	if rowNumber == 0 || int(rowNumber) > len(rows) || len(rows[rowNumber-1]) == 0 {
+		return r.fail(fmt.Errorf("%w：第%d行", ErrTitleRowMissing, rowNumber))
+	}
+
+	return r.AutoFilter(NewExcelCellRef(1, int(rowNumber)).Coordinate(), NewExcelCellRef(len(rows[rowNumber-1]), len(rows)).Coordinate())
+}
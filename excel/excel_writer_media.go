@@ -0,0 +1,52 @@
+package excel
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/xuri/excelize/v2"
+	_ "golang.org/x/image/tiff"
+)
+
+// excelImageExtensions 图片格式对应的扩展名
+var excelImageExtensions = map[string]string{"png": ".png", "jpeg": ".jpg", "gif": ".gif", "tiff": ".tiff"}
+
+// addImage 在单元格中插入图片（图片大于单元格时按比例缩小）
+func (r *ExcelWriter) addImage(coordinate string, content []byte) error {
+	_, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("无法识别图片格式：%w", err)
+	}
+	extension, exist := excelImageExtensions[format]
+	if !exist {
+		return fmt.Errorf("不支持的图片格式：%s", format)
+	}
+
+	return r.excel.AddPictureFromBytes(r.sheetName, coordinate, &excelize.Picture{
+		Extension: extension,
+		File:      content,
+		Format:    &excelize.GraphicOptions{AltText: coordinate, AutoFit: true, LockAspectRatio: true, Positioning: "oneCell"},
+	})
+}
+
+// setCellExtras 设置单元格超链接及批注
+func (r *ExcelWriter) setCellExtras(cell *ExcelCell) error {
+	if cell.GetHyperlink() != "" {
+		if err := r.excel.SetCellHyperLink(r.sheetName, cell.GetCoordinate(), cell.GetHyperlink(), cell.GetHyperlinkType()); err != nil {
+			return NewCellErrorByCoordinate(cell.GetCoordinate(), cell.GetContent(), fmt.Errorf("设置超链接错误：%w", err))
+		}
+	}
+
+	if cell.GetCommentText() != "" {
+		comment := excelize.Comment{Cell: cell.GetCoordinate(), Author: cell.GetCommentAuthor(), Text: cell.GetCommentText()}
+		if err := r.excel.AddComment(r.sheetName, comment); err != nil {
+			return NewCellErrorByCoordinate(cell.GetCoordinate(), cell.GetContent(), fmt.Errorf("添加批注错误：%w", err))
+		}
+	}
+
+	return nil
+}
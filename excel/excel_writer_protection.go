@@ -0,0 +1,113 @@
+package excel
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ProtectSheet 保护当前工作表（password为空时不设密码，已解除锁定的单元格仍可编辑）
+func (r *ExcelWriter) ProtectSheet(password string) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if r.stream != nil {
+		return r.fail(ErrStreamUnsupported)
+	}
+
+	if err := r.excel.ProtectSheet(r.sheetName, &excelize.SheetProtectionOptions{
+		Password:            password,
+		AlgorithmName:       algorithmName(password),
+		SelectLockedCells:   true,
+		SelectUnlockedCells: true,
+	}); err != nil {
+		return r.fail(fmt.Errorf("保护工作表错误：%w", err))
+	}
+
+	return r
+}
+
+// UnprotectSheet 取消保护当前工作表
+func (r *ExcelWriter) UnprotectSheet() *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if err := r.excel.UnprotectSheet(r.sheetName); err != nil {
+		return r.fail(fmt.Errorf("取消保护工作表错误：%w", err))
+	}
+	return r
+}
+
+// ProtectWorkbook 保护工作簿结构（禁止增删、重命名、移动及显示隐藏的工作表，password为空时不设密码）
+func (r *ExcelWriter) ProtectWorkbook(password string) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+
+	if err := r.excel.ProtectWorkbook(&excelize.WorkbookProtectionOptions{
+		Password:      password,
+		AlgorithmName: algorithmName(password),
+		LockStructure: true,
+	}); err != nil {
+		return r.fail(fmt.Errorf("保护工作簿错误：%w", err))
+	}
+
+	return r
+}
+
+// UnlockCells 解除单元格区域的锁定（保留单元格原有样式，如：B2、D100）
+func (r *ExcelWriter) UnlockCells(hCell, vCell string) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if r.stream != nil {
+		return r.fail(ErrStreamUnsupported)
+	}
+
+	area, err := ParseExcelRangeRef(hCell + ":" + vCell)
+	if err != nil {
+		return r.fail(fmt.Errorf("解除锁定错误：%w", err))
+	}
+
+	unlocked := make(map[int]int)
+
+	area.Each(func(cell ExcelCellRef) bool {
+		coordinate := cell.Coordinate()
+		styleID, err := r.excel.GetCellStyle(r.sheetName, coordinate)
+		if err != nil {
+			r.fail(NewCellErrorByCoordinate(coordinate, "", fmt.Errorf("解除锁定错误：%w", err)))
+			return false
+		}
+
+		unlockedStyleID, exist := unlocked[styleID]
+		if !exist {
+			excelStyle, err := r.excel.GetStyle(styleID)
+			if err != nil {
+				r.fail(NewCellErrorByCoordinate(coordinate, "", fmt.Errorf("解除锁定错误：%w", err)))
+				return false
+			}
+			excelStyle.Protection = &excelize.Protection{Locked: false}
+			if unlockedStyleID, err = r.newStyleID(excelStyle); err != nil {
+				r.fail(NewCellErrorByCoordinate(coordinate, "", fmt.Errorf("解除锁定错误：%w", err)))
+				return false
+			}
+			unlocked[styleID] = unlockedStyleID
+		}
+
+		if err = r.excel.SetCellStyle(r.sheetName, coordinate, coordinate, unlockedStyleID); err != nil {
+			r.fail(NewCellErrorByCoordinate(coordinate, "", fmt.Errorf("解除锁定错误：%w", err)))
+			return false
+		}
+		return true
+	})
+
+	return r
+}
+
+// algorithmName 密码哈希算法（无密码时为空）
+func algorithmName(password string) string {
+	if password == "" {
+		return ""
+	}
+	return "SHA-512"
+}
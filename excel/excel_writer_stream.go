@@ -0,0 +1,150 @@
+package excel
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// StartStream 开启流式写入（针对当前工作表）：行按行号递增顺序写入并随时落盘，适用于大量数据导出，写入完成后调用Flush或Save；
+// 流式写入会重建工作表，因此当前工作表已有数据时返回ErrStreamSheetNotEmpty（应先开启流式写入再写表头）
+func (r *ExcelWriter) StartStream() *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if r.stream != nil {
+		r.Flush()
+	}
+	if r.sheetName == "" {
+		return r.fail(ErrSheetNameNotSet)
+	}
+	rows, err := r.excel.Rows(r.sheetName)
+	if err != nil {
+		return r.fail(fmt.Errorf("开启流式写入错误：%w", err))
+	}
+	notEmpty := rows.Next()
+	if err = rows.Close(); err != nil {
+		return r.fail(fmt.Errorf("开启流式写入错误：%w", err))
+	}
+	if notEmpty {
+		return r.fail(fmt.Errorf("%w：%s", ErrStreamSheetNotEmpty, r.sheetName))
+	}
+
+	stream, err := r.excel.NewStreamWriter(r.sheetName)
+	if err != nil {
+		return r.fail(fmt.Errorf("开启流式写入错误：%w", err))
+	}
+	r.stream = stream
+	r.streamRow = 0
+
+	return r
+}
+
+// IsStream 是否处于流式写入模式
+func (r *ExcelWriter) IsStream() bool {
+	return r.stream != nil
+}
+
+// Flush 结束流式写入
+func (r *ExcelWriter) Flush() *ExcelWriter {
+	if r.stream == nil {
+		return r
+	}
+
+	stream := r.stream
+	r.stream = nil
+	if err := stream.Flush(); err != nil {
+		return r.fail(fmt.Errorf("流式写入错误：%w", err))
+	}
+
+	return r
+}
+
+// addStreamRow 流式写入一行
+func (r *ExcelWriter) addStreamRow(excelRow *ExcelRow) *ExcelWriter {
+	if excelRow.GetRowNumber() <= r.streamRow {
+		return r.fail(fmt.Errorf("%w：%d", ErrStreamRowOrder, excelRow.GetRowNumber()))
+	}
+
+	var values []any
+	for _, cell := range excelRow.GetCells() {
+		ref, err := ParseExcelCellRef(cell.GetCoordinate())
+		if err != nil {
+			return r.fail(NewCellErrorByCoordinate(cell.GetCoordinate(), cell.GetContent(), err))
+		}
+		if uint64(ref.Row) != excelRow.GetRowNumber() {
+			return r.fail(NewCellErrorByCoordinate(cell.GetCoordinate(), cell.GetContent(), fmt.Errorf("单元格不属于第%d行", excelRow.GetRowNumber())))
+		}
+		if cell.GetContentType() == ExcelCellContentTypeImage || cell.GetContentType() == ExcelCellContentTypeRichText || cell.GetHyperlink() != "" || cell.GetCommentText() != "" {
+			return r.fail(NewCellErrorByCoordinate(cell.GetCoordinate(), cell.GetContent(), ErrStreamUnsupported))
+		}
+
+		streamCell, err := r.newStreamCell(cell)
+		if err != nil {
+			return r.fail(err)
+		}
+
+		for len(values) < ref.Col {
+			values = append(values, nil)
+		}
+		values[ref.Col-1] = streamCell
+	}
+
+	if err := r.stream.SetRow(NewExcelCellRef(1, int(excelRow.GetRowNumber())).Coordinate(), values); err != nil {
+		return r.fail(fmt.Errorf("流式写入错误（第%d行）：%w", excelRow.GetRowNumber(), err))
+	}
+	r.streamRow = excelRow.GetRowNumber()
+
+	return r
+}
+
+// newStreamCell 转换为流式写入单元格
+func (r *ExcelWriter) newStreamCell(cell *ExcelCell) (excelize.Cell, error) {
+	styleID, err := r.getStyleID(cell)
+	if err != nil {
+		return excelize.Cell{}, NewCellErrorByCoordinate(cell.GetCoordinate(), cell.GetContent(), fmt.Errorf("设置字体错误：%w", err))
+	}
+
+	streamCell := excelize.Cell{StyleID: styleID}
+	switch cell.GetContentType() {
+	case ExcelCellContentTypeFormula:
+		content, ok := cell.GetContent().(string)
+		if !ok {
+			return streamCell, NewCellErrorByCoordinate(cell.GetCoordinate(), cell.GetContent(), fmt.Errorf("写入数据错误（公式）：%w", ErrCellContentInvalid))
+		}
+		streamCell.Formula = content
+	case ExcelCellContentTypeInt:
+		if _, ok := cell.GetContent().(int); !ok {
+			return streamCell, NewCellErrorByCoordinate(cell.GetCoordinate(), cell.GetContent(), fmt.Errorf("写入数据错误（数字）：%w", ErrCellContentInvalid))
+		}
+		streamCell.Value = cell.GetContent()
+	case ExcelCellContentTypeFloat64:
+		content, ok := cell.GetContent().(float64)
+		if !ok {
+			return streamCell, NewCellErrorByCoordinate(cell.GetCoordinate(), cell.GetContent(), fmt.Errorf("写入数据错误（小数）：%w", ErrCellContentInvalid))
+		}
+		streamCell.Value = roundExcelFloat(content)
+	case ExcelCellContentTypeBool:
+		if _, ok := cell.GetContent().(bool); !ok {
+			return streamCell, NewCellErrorByCoordinate(cell.GetCoordinate(), cell.GetContent(), fmt.Errorf("写入数据错误（布尔）：%w", ErrCellContentInvalid))
+		}
+		streamCell.Value = cell.GetContent()
+	case ExcelCellContentTypeDate, ExcelCellContentTypeDateTime, ExcelCellContentTypeTime:
+		content, ok := cell.GetContent().(time.Time)
+		if !ok {
+			return streamCell, NewCellErrorByCoordinate(cell.GetCoordinate(), cell.GetContent(), fmt.Errorf("写入数据错误（日期）：%w", ErrCellContentInvalid))
+		}
+		streamCell.Value = excelTimeValue(content)
+	case ExcelCellContentTypeDuration:
+		content, ok := cell.GetContent().(time.Duration)
+		if !ok {
+			return streamCell, NewCellErrorByCoordinate(cell.GetCoordinate(), cell.GetContent(), fmt.Errorf("写入数据错误（时长）：%w", ErrCellContentInvalid))
+		}
+		streamCell.Value = durationToExcelDays(content)
+	default:
+		streamCell.Value = cell.GetContent()
+	}
+
+	return streamCell, nil
+}
@@ -0,0 +1,53 @@
+package excel
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestStartStreamSheetNotEmpty(t *testing.T) {
+	writer := NewExcelWriter(filepath.Join(t.TempDir(), "stream.xlsx")).SetCollectErr(true).ActiveSheetByIndex(0)
+	writer.SetTitleRow([]string{"名称"}, 1).StartStream()
+	if err := writer.Err(); !errors.Is(err, ErrStreamSheetNotEmpty) {
+		t.Fatalf("StartStream: got %v, want %v", err, ErrStreamSheetNotEmpty)
+	}
+}
+
+func TestStreamFloatPrecision(t *testing.T) {
+	var (
+		dir   = t.TempDir()
+		value = 1.23456789
+	)
+
+	for _, stream := range []bool{false, true} {
+		filename := filepath.Join(dir, "normal.xlsx")
+		writer := NewExcelWriter(filename).SetCollectErr(true).ActiveSheetByIndex(0)
+		if stream {
+			filename = filepath.Join(dir, "stream.xlsx")
+			writer = NewExcelWriter(filename).SetCollectErr(true).ActiveSheetByIndex(0).StartStream()
+		}
+		writer.AddRow(NewExcelRow().SetRowNumber(1).SetCells([]*ExcelCell{NewExcelCellFloat64(value)}))
+		if err := writer.Save(); err != nil {
+			t.Fatalf("Save(stream=%v): %v", stream, err)
+		}
+		if err := writer.Err(); err != nil {
+			t.Fatalf("Write(stream=%v): %v", stream, err)
+		}
+
+		f, err := excelize.OpenFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := f.GetCellValue("Sheet1", "A1", excelize.Options{RawCellValue: true})
+		_ = f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != "1.2346" {
+			t.Errorf("stream=%v: got %s, want 1.2346", stream, got)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package excel
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type (
+	// ExcelStructFormatter 结构体列格式化函数
+	ExcelStructFormatter func(value any) any
+
+	// WriteStructsOption 结构体写入选项
+	WriteStructsOption struct {
+		titleRow   uint64
+		formatters map[string]ExcelStructFormatter
+	}
+
+	// excelColumn 结构体导出列
+	excelColumn struct {
+		excelField
+		order int
+	}
+)
+
+// NewWriteStructsOption 构造函数（默认第一行是表头）
+func NewWriteStructsOption() *WriteStructsOption {
+	return &WriteStructsOption{titleRow: 1, formatters: make(map[string]ExcelStructFormatter)}
+}
+
+// GetTitleRow 获取表头行
+func (r *WriteStructsOption) GetTitleRow() uint64 {
+	return r.titleRow
+}
+
+// SetTitleRow 设置表头行
+func (r *WriteStructsOption) SetTitleRow(titleRow uint64) *WriteStructsOption {
+	r.titleRow = titleRow
+	return r
+}
+
+// SetFormatter 设置列格式化函数（根据标题）
+func (r *WriteStructsOption) SetFormatter(title string, formatter ExcelStructFormatter) *WriteStructsOption {
+	r.formatters[title] = formatter
+	return r
+}
+
+// GetFormatter 获取列格式化函数
+func (r *WriteStructsOption) GetFormatter(title string) ExcelStructFormatter {
+	return r.formatters[title]
+}
+
+// structColumns 获取结构体导出列（根据order排序，未设置时按字段声明顺序）
+func structColumns(typ reflect.Type) ([]excelColumn, error) {
+	var (
+		fields  = structFields(typ)
+		columns = make([]excelColumn, len(fields))
+	)
+
+	for idx, field := range fields {
+		order := idx + 1
+		if _order, exist := field.tag.options["order"]; exist {
+			o, err := strconv.Atoi(_order)
+			if err != nil {
+				return nil, fmt.Errorf("列顺序错误：%s %s", field.title, _order)
+			}
+			order = o
+		}
+		columns[idx] = excelColumn{excelField: field, order: order}
+	}
+	sort.SliceStable(columns, func(i, j int) bool { return columns[i].order < columns[j].order })
+
+	return columns, nil
+}
+
+// newExcelCellByValue 根据值类型创建单元格
+func newExcelCellByValue(value any) *ExcelCell {
+	if value == nil {
+		return NewExcelCellAny("")
+	}
+
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return NewExcelCellAny("")
+		}
+		v = v.Elem()
+	}
+
+	switch v.Type() {
+	case timeType:
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return NewExcelCellAny("")
+		}
+		return NewExcelCellDateTime(t)
+	case durationType:
+		return NewExcelCellDuration(v.Interface().(time.Duration))
+	}
+
+	if marshaler, ok := v.Interface().(encoding.TextMarshaler); ok {
+		if text, err := marshaler.MarshalText(); err == nil {
+			return NewExcelCellAny(string(text))
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return NewExcelCellInt(int(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		// 超出int范围时写入文本，避免溢出为负数
+		if v.Uint() > math.MaxInt {
+			return NewExcelCellAny(strconv.FormatUint(v.Uint(), 10))
+		}
+		return NewExcelCellInt(int(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return NewExcelCellFloat64(v.Float())
+	case reflect.Bool:
+		return NewExcelCellBool(v.Bool())
+	case reflect.String:
+		return NewExcelCellAny(v.String())
+	default:
+		return NewExcelCellAny(v.Interface())
+	}
+}
+
+// WriteStructs 写入结构体切片，表头、列顺序、列宽、数字格式由`excel:"标题,order=1,width=20,format=0.00"`标签决定
+func (r *ExcelWriter) WriteStructs(data any, opt *WriteStructsOption) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if opt == nil {
+		opt = NewWriteStructsOption()
+	}
+
+	sliceValue := reflect.ValueOf(data)
+	for sliceValue.Kind() == reflect.Pointer {
+		sliceValue = sliceValue.Elem()
+	}
+	if sliceValue.Kind() != reflect.Slice && sliceValue.Kind() != reflect.Array {
+		return r.fail(errors.New("数据必须为切片"))
+	}
+
+	structType := sliceValue.Type().Elem()
+	for structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return r.fail(errors.New("切片元素必须为结构体"))
+	}
+
+	columns, err := structColumns(structType)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	titles := make([]string, len(columns))
+
+	for idx, column := range columns {
+		titles[idx] = column.title
+		if width, exist := column.tag.options["width"]; exist {
+			w, err := strconv.ParseFloat(width, 64)
+			if err != nil {
+				return r.fail(fmt.Errorf("列宽错误：%s %s", column.title, width))
+			}
+			if err = r.setColWidth(idx+1, idx+1, w); err != nil {
+				return r.fail(fmt.Errorf("设置列宽错误：%w", err))
+			}
+		}
+	}
+	r.SetTitleRow(titles, opt.GetTitleRow())
+
+	for idx := 0; idx < sliceValue.Len(); idx++ {
+		var (
+			elem  = sliceValue.Index(idx)
+			cells = make([]*ExcelCell, len(columns))
+		)
+
+		for elem.Kind() == reflect.Pointer && !elem.IsNil() {
+			elem = elem.Elem()
+		}
+
+		for colIdx, column := range columns {
+			if elem.Kind() != reflect.Struct {
+				cells[colIdx] = NewExcelCellAny("")
+				continue
+			}
+
+			var value any
+			if field := fieldByIndexSafe(elem, column.index); field.IsValid() {
+				value = field.Interface()
+			}
+
+			if formatter := opt.GetFormatter(column.title); formatter != nil {
+				value = formatter(value)
+			}
+
+			cells[colIdx] = newExcelCellByValue(value)
+			if format, exist := column.tag.options["format"]; exist {
+				cells[colIdx].SetNumberFormat(format)
+			}
+		}
+
+		r.AddRow(NewExcelRow().SetCollectErr(r.collectErr).SetRowNumber(opt.GetTitleRow() + uint64(idx) + 1).SetCells(cells))
+	}
+
+	return r
+}
@@ -0,0 +1,24 @@
+package excel
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewExcelCellByValueUint64(t *testing.T) {
+	tests := []struct {
+		value       uint64
+		contentType ExcelCellContentType
+		content     any
+	}{
+		{value: 42, contentType: ExcelCellContentTypeInt, content: 42},
+		{value: math.MaxUint64, contentType: ExcelCellContentTypeAny, content: "18446744073709551615"},
+	}
+
+	for _, tt := range tests {
+		cell := newExcelCellByValue(tt.value)
+		if cell.GetContentType() != tt.contentType || cell.GetContent() != tt.content {
+			t.Errorf("newExcelCellByValue(%d): got %s %v, want %s %v", tt.value, cell.GetContentType(), cell.GetContent(), tt.contentType, tt.content)
+		}
+	}
+}
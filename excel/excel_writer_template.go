@@ -0,0 +1,420 @@
+package excel
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// formulaRowRef 公式引用中的行号（单个单元格时起点与终点相同）
+type formulaRowRef struct {
+	start         int
+	end           int
+	startAbsolute bool
+	endAbsolute   bool
+	isRange       bool
+}
+
+var (
+	// templateRangePattern 行展开标记（如：{{range .Items}}）
+	templateRangePattern = regexp.MustCompile(`^\{\{-?\s*range\s+([^{}]+?)\s*-?\}\}`)
+	// templateEndPattern 行展开结束标记（可省略）
+	templateEndPattern = regexp.MustCompile(`\{\{-?\s*end\s*-?\}\}`)
+	// templateValuePattern 整个单元格只有一个取值表达式（如：{{.Customer.Name}}），按原类型写入
+	templateValuePattern = regexp.MustCompile(`^\{\{-?\s*([.$][^{}]*?)\s*-?\}\}$`)
+	// formulaRefPattern 公式中的单元格引用或区域，可带工作表名称，不区分大小写（如：A1、$B$2、c3:d4、Sheet1!A5、'My Sheet'!A5）
+	formulaRefPattern = regexp.MustCompile(`(?i)(?:('(?:[^']|'')+'|[\p{L}\p{N}_.]+)!)?(\$?[A-Z]{1,3})(\$?)(\d+)(?::(\$?[A-Z]{1,3})(\$?)(\d+))?`)
+)
+
+// NewExcelWriterByTemplate 根据模板初始化（模板文件不会被修改，保存到filename）
+func NewExcelWriterByTemplate(template, filename string, a ...any) *ExcelWriter {
+	return (&ExcelWriter{}).InitByTemplate(template, fmt.Sprintf(filename, a...))
+}
+
+// InitByTemplate 根据模板初始化
+func (r *ExcelWriter) InitByTemplate(template, filename string) *ExcelWriter {
+	if template == "" || filename == "" {
+		return r.fail(ErrFilenameEmpty)
+	}
+	f, err := excelize.OpenFile(template)
+	if err != nil {
+		return r.fail(fmt.Errorf("打开模板错误：%w", err))
+	}
+	return r.initFile(filename, f)
+}
+
+// InitByTemplateReader 根据模板初始化（从io.Reader读取模板）
+func (r *ExcelWriter) InitByTemplateReader(template io.Reader, filename string) *ExcelWriter {
+	if filename == "" {
+		return r.fail(ErrFilenameEmpty)
+	}
+	f, err := excelize.OpenReader(template)
+	if err != nil {
+		return r.fail(fmt.Errorf("打开模板错误：%w", err))
+	}
+	return r.initFile(filename, f)
+}
+
+// Render 渲染全部工作表
+func (r *ExcelWriter) Render(data any) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	for _, sheetName := range r.excel.GetSheetList() {
+		if r.RenderSheet(sheetName, data); r.failed() {
+			break
+		}
+	}
+	return r
+}
+
+// RenderSheet 渲染工作表：替换单元格中的占位符（如：{{.Customer.Name}}、合计：{{.Total}}元），
+// 并将以{{range .Items}}开头的单元格所在行按切片展开为多行（复制样式，下方的公式引用及合并单元格随之下移，
+// 展开行中的占位符以切片元素为数据，如：{{.Name}}；切片为空时删除该行，以该行为端点的区域随之收缩）
+func (r *ExcelWriter) RenderSheet(sheetName string, data any) *ExcelWriter {
+	if r.failed() {
+		return r
+	}
+	if r.stream != nil {
+		return r.fail(ErrStreamUnsupported)
+	}
+	if sheetIndex, err := r.excel.GetSheetIndex(sheetName); err != nil || sheetIndex == -1 {
+		return r.fail(fmt.Errorf("%w：%s", ErrSheetNotFound, sheetName))
+	}
+
+	currentSheetName := r.sheetName
+	r.sheetName = sheetName
+	defer func() { r.sheetName = currentSheetName }()
+
+	rows, err := r.excel.GetRows(sheetName)
+	if err != nil {
+		return r.fail(fmt.Errorf("读取模板错误：%w", err))
+	}
+
+	// 先渲染普通行，再自下而上展开标记行，避免行号变化影响尚未处理的标记
+	var (
+		rangeRows  []int
+		rangeExprs = make(map[int]string)
+	)
+	for rowIdx, row := range rows {
+		rowNumber := rowIdx + 1
+		for _, content := range row {
+			if match := templateRangePattern.FindStringSubmatch(content); match != nil {
+				rangeRows = append(rangeRows, rowNumber)
+				rangeExprs[rowNumber] = match[1]
+				break
+			}
+		}
+		if _, exist := rangeExprs[rowNumber]; exist {
+			continue
+		}
+		if err = r.renderRow(rowNumber, row, data); err != nil {
+			return r.fail(err)
+		}
+	}
+
+	for idx := len(rangeRows) - 1; idx >= 0; idx-- {
+		if err = r.renderRangeRow(rangeRows[idx], rows[rangeRows[idx]-1], rangeExprs[rangeRows[idx]], data); err != nil {
+			return r.fail(err)
+		}
+	}
+
+	return r
+}
+
+// renderRangeRow 展开标记行
+func (r *ExcelWriter) renderRangeRow(rowNumber int, row []string, expr string, data any) error {
+	value, err := evalTemplateValue(expr, data)
+	if err != nil {
+		coordinate := NewExcelCellRef(1, rowNumber).Coordinate()
+		return NewCellErrorByCoordinate(coordinate, expr, fmt.Errorf("渲染模板错误：%w", err))
+	}
+
+	items := reflect.ValueOf(value)
+	for items.Kind() == reflect.Pointer || items.Kind() == reflect.Interface {
+		items = items.Elem()
+	}
+	if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+		coordinate := NewExcelCellRef(1, rowNumber).Coordinate()
+		return NewCellErrorByCoordinate(coordinate, expr, fmt.Errorf("渲染模板错误：%s不是切片", expr))
+	}
+
+	// 去掉标记后的行内容作为每个元素的模板
+	cells := make([]string, len(row))
+	for colIdx, content := range row {
+		content = templateRangePattern.ReplaceAllString(content, "")
+		cells[colIdx] = templateEndPattern.ReplaceAllString(content, "")
+	}
+
+	// excelize增删行时不会扩展或收缩区域引用，先记录原公式，行变化后据此重新写入
+	formulas, err := r.sheetFormulas()
+	if err != nil {
+		return err
+	}
+
+	if items.Len() == 0 {
+		if err = r.excel.RemoveRow(r.sheetName, rowNumber); err != nil {
+			return fmt.Errorf("删除行错误（第%d行）：%w", rowNumber, err)
+		}
+		return r.setShiftedFormulas(formulas, rowNumber, -1)
+	}
+
+	for idx := 1; idx < items.Len(); idx++ {
+		if err = r.excel.DuplicateRowTo(r.sheetName, rowNumber, rowNumber+idx); err != nil {
+			return fmt.Errorf("复制行错误（第%d行）：%w", rowNumber, err)
+		}
+	}
+	if err = r.setShiftedFormulas(formulas, rowNumber, items.Len()-1); err != nil {
+		return err
+	}
+
+	for idx := 0; idx < items.Len(); idx++ {
+		if err = r.renderRow(rowNumber+idx, cells, items.Index(idx).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderRow 渲染一行中的占位符
+func (r *ExcelWriter) renderRow(rowNumber int, row []string, data any) error {
+	for colIdx, content := range row {
+		if !strings.Contains(content, "{{") {
+			continue
+		}
+		coordinate := NewExcelCellRef(colIdx+1, rowNumber).Coordinate()
+		if err := r.renderCell(coordinate, content, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderCell 渲染单元格（整个单元格为单个取值表达式时按原类型写入，否则按文本写入）
+func (r *ExcelWriter) renderCell(coordinate, content string, data any) error {
+	if match := templateValuePattern.FindStringSubmatch(content); match != nil {
+		value, err := evalTemplateValue(match[1], data)
+		if err != nil {
+			return NewCellErrorByCoordinate(coordinate, content, fmt.Errorf("渲染模板错误：%w", err))
+		}
+		return r.setCell(newExcelCellByValue(value).SetCoordinate(coordinate))
+	}
+
+	tmpl, err := template.New(coordinate).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return NewCellErrorByCoordinate(coordinate, content, fmt.Errorf("解析模板错误：%w", err))
+	}
+	var buffer bytes.Buffer
+	if err = tmpl.Execute(&buffer, data); err != nil {
+		return NewCellErrorByCoordinate(coordinate, content, fmt.Errorf("渲染模板错误：%w", err))
+	}
+	if err = r.excel.SetCellStr(r.sheetName, coordinate, buffer.String()); err != nil {
+		return NewCellErrorByCoordinate(coordinate, content, fmt.Errorf("写入数据错误：%w", err))
+	}
+
+	return nil
+}
+
+// evalTemplateValue 计算模板表达式的值（保留原类型）
+func evalTemplateValue(expr string, data any) (any, error) {
+	var value any
+	tmpl, err := template.New("").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"capture": func(v any) string { value = v; return "" }}).
+		Parse("{{capture (" + expr + ")}}")
+	if err != nil {
+		return nil, err
+	}
+	if err = tmpl.Execute(io.Discard, data); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// sheetFormulas 读取当前工作表的全部公式
+func (r *ExcelWriter) sheetFormulas() (map[ExcelCellRef]string, error) {
+	rows, err := r.excel.GetRows(r.sheetName, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, fmt.Errorf("读取数据错误：%w", err)
+	}
+
+	cols := 0
+	for _, row := range rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+
+	formulas := make(map[ExcelCellRef]string)
+	for rowIdx := range rows {
+		for col := 1; col <= cols; col++ {
+			cell := NewExcelCellRef(col, rowIdx+1)
+			formula, err := r.excel.GetCellFormula(r.sheetName, cell.Coordinate())
+			if err != nil {
+				return nil, NewCellErrorByCoordinate(cell.Coordinate(), "", fmt.Errorf("读取公式错误：%w", err))
+			}
+			if formula != "" {
+				formulas[cell] = formula
+			}
+		}
+	}
+
+	return formulas, nil
+}
+
+// setShiftedFormulas 在第rowNumber行之后插入offset行（offset为-1时表示删除第rowNumber行）后，根据原公式重新写入公式：
+// 下方的引用随之移动，以第rowNumber行为终点的区域随之扩展（如：SUM(D5:D5)展开为SUM(D5:D7)），
+// 删除时以该行为端点的区域随之收缩，仅引用该行的引用变为#REF!；展开行中指向本行的相对引用改为指向新行
+func (r *ExcelWriter) setShiftedFormulas(formulas map[ExcelCellRef]string, rowNumber, offset int) error {
+	for cell, formula := range formulas {
+		switch {
+		case cell.Row == rowNumber && offset < 0:
+			continue
+		case cell.Row == rowNumber:
+			formula = shiftFormulaRows(formula, r.sheetName, rowNumber, offset, false)
+			for idx := 1; idx <= offset; idx++ {
+				if err := r.setTemplateFormula(NewExcelCellRef(cell.Col, rowNumber+idx), moveFormulaRow(formula, r.sheetName, rowNumber, rowNumber+idx)); err != nil {
+					return err
+				}
+			}
+		case cell.Row > rowNumber:
+			cell.Row += offset
+			formula = shiftFormulaRows(formula, r.sheetName, rowNumber, offset, true)
+		default:
+			formula = shiftFormulaRows(formula, r.sheetName, rowNumber, offset, true)
+		}
+		if err := r.setTemplateFormula(cell, formula); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setTemplateFormula 写入公式
+func (r *ExcelWriter) setTemplateFormula(cell ExcelCellRef, formula string) error {
+	if err := r.excel.SetCellFormula(r.sheetName, cell.Coordinate(), formula); err != nil {
+		return NewCellErrorByCoordinate(cell.Coordinate(), formula, fmt.Errorf("写入公式错误：%w", err))
+	}
+	return nil
+}
+
+// shiftFormulaRows 调整公式中sheetName工作表的行引用（规则同setShiftedFormulas，expand为false时不扩展区域，用于展开行自身的公式）
+func shiftFormulaRows(formula, sheetName string, rowNumber, offset int, expand bool) string {
+	return replaceFormulaRows(formula, sheetName, func(ref formulaRowRef) (formulaRowRef, bool) {
+		if offset < 0 {
+			if ref.start == rowNumber && ref.end == rowNumber {
+				return ref, false
+			}
+			if ref.start > rowNumber {
+				ref.start--
+			}
+			if ref.end >= rowNumber {
+				ref.end--
+			}
+			return ref, true
+		}
+
+		if ref.start > rowNumber {
+			ref.start += offset
+		}
+		if ref.end > rowNumber || expand && ref.isRange && ref.end == rowNumber && ref.start <= rowNumber {
+			ref.end += offset
+		}
+		return ref, true
+	})
+}
+
+// moveFormulaRow 将公式中指向fromRowNumber行的相对引用改为指向toRowNumber行
+func moveFormulaRow(formula, sheetName string, fromRowNumber, toRowNumber int) string {
+	return replaceFormulaRows(formula, sheetName, func(ref formulaRowRef) (formulaRowRef, bool) {
+		if ref.start == fromRowNumber && !ref.startAbsolute {
+			ref.start = toRowNumber
+		}
+		if ref.end == fromRowNumber && !ref.endAbsolute {
+			ref.end = toRowNumber
+		}
+		return ref, true
+	})
+}
+
+// replaceFormulaRows 替换公式中的行号（不带工作表名称或工作表名称为sheetName的引用，跳过字符串常量、函数名、其他工作表及三维引用），
+// adjust返回false时该引用替换为#REF!
+func replaceFormulaRows(formula, sheetName string, adjust func(ref formulaRowRef) (formulaRowRef, bool)) string {
+	var (
+		builder strings.Builder
+		parts   = strings.Split(formula, `"`)
+	)
+	for idx, part := range parts {
+		if idx > 0 {
+			builder.WriteString(`"`)
+		}
+		if idx%2 == 1 {
+			builder.WriteString(part)
+			continue
+		}
+
+		last := 0
+		for _, loc := range formulaRefPattern.FindAllStringSubmatchIndex(part, -1) {
+			if loc[0] > 0 && isFormulaNameChar(part[loc[0]-1], true) || loc[1] < len(part) && (isFormulaNameChar(part[loc[1]], false) || part[loc[1]] == '(') {
+				continue
+			}
+			if loc[2] != -1 && (loc[0] > 0 && part[loc[0]-1] == ':' || !strings.EqualFold(unquoteSheetName(part[loc[2]:loc[3]]), sheetName)) {
+				continue
+			}
+
+			ref := formulaRowRef{startAbsolute: loc[7] > loc[6], isRange: loc[10] != -1}
+			ref.start, _ = strconv.Atoi(part[loc[8]:loc[9]])
+			ref.end, ref.endAbsolute = ref.start, ref.startAbsolute
+			if ref.isRange {
+				ref.end, _ = strconv.Atoi(part[loc[14]:loc[15]])
+				ref.endAbsolute = loc[13] > loc[12]
+			}
+
+			builder.WriteString(part[last:loc[0]])
+			last = loc[1]
+			adjusted, ok := adjust(ref)
+			if !ok {
+				builder.WriteString("#REF!")
+				continue
+			}
+			builder.WriteString(part[loc[0]:loc[7]])
+			builder.WriteString(strconv.Itoa(adjusted.start))
+			if ref.isRange {
+				builder.WriteString(":")
+				builder.WriteString(part[loc[10]:loc[13]])
+				builder.WriteString(strconv.Itoa(adjusted.end))
+			}
+		}
+		builder.WriteString(part[last:])
+	}
+
+	return builder.String()
+}
+
+// unquoteSheetName 去除公式中工作表名称的引号（如：'My Sheet'，名称中的单引号写作两个单引号）
+func unquoteSheetName(name string) string {
+	if len(name) >= 2 && name[0] == '\'' && name[len(name)-1] == '\'' {
+		return strings.ReplaceAll(name[1:len(name)-1], "''", "'")
+	}
+	return name
+}
+
+// isFormulaNameChar 是否为名称字符（用于判断引用边界，before为true时判断引用之前的字符）
+func isFormulaNameChar(char byte, before bool) bool {
+	switch {
+	case char >= 'A' && char <= 'Z', char >= 'a' && char <= 'z', char >= '0' && char <= '9', char == '_', char == '.':
+		return true
+	case before && (char == '!' || char == '$'):
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,141 @@
+package excel
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type templateItem struct {
+	Name string
+	Qty  int
+}
+
+// newTemplateReader 明细在第2行展开，第3、4行为合计，A5:B6为合并单元格
+func newTemplateReader(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	f := excelize.NewFile()
+	defer f.Close()
+	for cell, value := range map[string]string{"A1": "名称", "D1": "数量", "A2": "{{range .Items}}{{.Name}}", "D2": "{{.Qty}}", "A5": "备注"} {
+		if err := f.SetCellValue("Sheet1", cell, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for cell, formula := range map[string]string{"E2": "D2*2", "D3": "SUM(D2:D2)", "E3": "SUM(D1:D2)+$D$3", "D4": "D3*2+D2"} {
+		if err := f.SetCellFormula("Sheet1", cell, formula); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.MergeCell("Sheet1", "A5", "B6"); err != nil {
+		t.Fatal(err)
+	}
+
+	var buffer bytes.Buffer
+	if err := f.Write(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	return &buffer
+}
+
+func TestRenderSheetRangeRow(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    int
+		formulas map[string]string
+		merge    [2]string
+	}{
+		{
+			name:     "empty",
+			items:    0,
+			formulas: map[string]string{"D2": "SUM(#REF!)", "E2": "SUM(D1:D1)+$D$2", "D3": "D2*2+#REF!"},
+			merge:    [2]string{"A4", "B5"},
+		},
+		{
+			name:     "single",
+			items:    1,
+			formulas: map[string]string{"E2": "D2*2", "D3": "SUM(D2:D2)", "E3": "SUM(D1:D2)+$D$3", "D4": "D3*2+D2"},
+			merge:    [2]string{"A5", "B6"},
+		},
+		{
+			name:     "multiple",
+			items:    3,
+			formulas: map[string]string{"E2": "D2*2", "E3": "D3*2", "E4": "D4*2", "D5": "SUM(D2:D4)", "E5": "SUM(D1:D4)+$D$5", "D6": "D5*2+D2"},
+			merge:    [2]string{"A7", "B8"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			items := make([]templateItem, test.items)
+			for idx := range items {
+				items[idx] = templateItem{Name: fmt.Sprintf("商品%d", idx+1), Qty: idx + 1}
+			}
+
+			writer := new(ExcelWriter).SetCollectErr(true).InitByTemplateReader(newTemplateReader(t), "render.xlsx").Render(map[string]any{"Items": items})
+			if err := writer.Err(); err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+
+			for idx, item := range items {
+				name, err := writer.excel.GetCellValue("Sheet1", NewExcelCellRef(1, idx+2).Coordinate())
+				if err != nil || name != item.Name {
+					t.Errorf("row %d name: got %q (%v), want %q", idx+2, name, err, item.Name)
+				}
+			}
+
+			for row := 1; row <= test.items+5; row++ {
+				for col := 1; col <= 5; col++ {
+					coordinate := NewExcelCellRef(col, row).Coordinate()
+					formula, err := writer.excel.GetCellFormula("Sheet1", coordinate)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if want := test.formulas[coordinate]; formula != want {
+						t.Errorf("%s formula: got %q, want %q", coordinate, formula, want)
+					}
+				}
+			}
+
+			mergeCells, err := writer.excel.GetMergeCells("Sheet1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(mergeCells) != 1 || mergeCells[0].GetStartAxis() != test.merge[0] || mergeCells[0].GetEndAxis() != test.merge[1] {
+				t.Errorf("merge cells: got %v, want %v", mergeCells, test.merge)
+			}
+		})
+	}
+}
+
+func TestShiftFormulaRowsSheetPrefix(t *testing.T) {
+	tests := []struct {
+		formula string
+		want    string
+	}{
+		{formula: "SUM(D2:D2)+D3", want: "SUM(D2:D4)+D5"},
+		{formula: "sum(d2:d2)+d3", want: "sum(d2:d4)+d5"},
+		{formula: "Sheet1!D3*2", want: "Sheet1!D5*2"},
+		{formula: "SHEET1!$D$3", want: "SHEET1!$D$5"},
+		{formula: "SUM(Sheet1!D2:D2)", want: "SUM(Sheet1!D2:D4)"},
+		{formula: "Other!D3+'Other Sheet'!D3", want: "Other!D3+'Other Sheet'!D3"},
+		{formula: "SUM(Sheet1:Sheet2!D3)", want: "SUM(Sheet1:Sheet2!D3)"},
+		{formula: `"D3"&Sheet1!D3`, want: `"D3"&Sheet1!D5`},
+		{formula: "LOG10(D3)", want: "LOG10(D5)"},
+	}
+
+	for _, tt := range tests {
+		if got := shiftFormulaRows(tt.formula, "Sheet1", 2, 2, true); got != tt.want {
+			t.Errorf("shiftFormulaRows(%q): got %q, want %q", tt.formula, got, tt.want)
+		}
+	}
+
+	if got := shiftFormulaRows("'My Sheet'!D3+'Bob''s'!D3", "My Sheet", 2, 2, true); got != "'My Sheet'!D5+'Bob''s'!D3" {
+		t.Errorf("shiftFormulaRows(quoted): got %q", got)
+	}
+	if got := shiftFormulaRows("'Bob''s'!D3", "Bob's", 2, 2, true); got != "'Bob''s'!D5" {
+		t.Errorf("shiftFormulaRows(escaped quote): got %q", got)
+	}
+}
@@ -4,7 +4,9 @@ go 1.20
 
 require (
 	github.com/go-gota/gota v0.12.0
-	github.com/xuri/excelize/v2 v2.6.0
+	github.com/xuri/excelize/v2 v2.8.1
+	golang.org/x/image v0.14.0
+	golang.org/x/text v0.14.0
 )
 
 require (
@@ -15,6 +17,5 @@ require (
 	github.com/xuri/nfp v0.0.0-20230919160717-d98342af3f05 // indirect
 	golang.org/x/crypto v0.19.0 // indirect
 	golang.org/x/net v0.21.0 // indirect
-	golang.org/x/text v0.14.0 // indirect
 	gonum.org/v1/gonum v0.9.1 // indirect
 )